@@ -0,0 +1,210 @@
+// Package audioconnector implements the WebSocket server side of the OpenTok
+// Audio Connector.
+//
+// OpenTok opens a WebSocket connection to your server, sends a JSON text
+// message describing the audio format and your custom headers, and then sends
+// the mixed audio of the session as binary messages of 16-bit linear PCM
+// samples (little-endian, mono).
+//
+// Audio Connector: https://tokbox.com/developer/guides/audio-connector
+package audioconnector
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Header defines the initial message sent by OpenTok.
+type Header struct {
+	// The content type of the audio, such as "audio/l16;rate=16000".
+	ContentType string
+
+	// The audio sample rate, in Hz.
+	SampleRate int
+
+	// The custom headers set when connecting the audio to the WebSocket.
+	Headers map[string]string
+}
+
+// Conn is an Audio Connector connection. Read returns the raw PCM audio.
+type Conn struct {
+	// The initial message sent by OpenTok.
+	Header *Header
+
+	ws     *websocket.Conn
+	reader io.Reader
+}
+
+// HandlerOptions defines the options for handling Audio Connector
+// connections.
+type HandlerOptions struct {
+	// OnError is called when a connection is rejected, for example because
+	// the initial message is invalid. By default, the error is logged with
+	// the standard logger.
+	OnError func(r *http.Request, err error)
+}
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 1024,
+}
+
+// Accept upgrades the HTTP request to a WebSocket connection and reads the
+// initial message. If the request is not a WebSocket handshake, an HTTP error
+// is written. If the initial message is invalid, the connection is closed with
+// the error as the close reason.
+func Accept(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	messageType, data, err := ws.ReadMessage()
+	if err != nil {
+		ws.Close()
+		return nil, err
+	}
+
+	if messageType != websocket.TextMessage {
+		err := fmt.Errorf("Expected the initial message to be a text message")
+		closeWithError(ws, websocket.CloseUnsupportedData, err)
+		return nil, err
+	}
+
+	header, err := parseHeader(data)
+	if err != nil {
+		closeWithError(ws, websocket.CloseProtocolError, err)
+		return nil, err
+	}
+
+	return &Conn{
+		Header: header,
+		ws:     ws,
+	}, nil
+}
+
+// Handler returns an http.Handler that accepts Audio Connector connections
+// and passes them to fn. The connection is closed when fn returns. Rejected
+// connections are reported to opts.OnError.
+func Handler(fn func(*Conn), opts *HandlerOptions) http.Handler {
+	onError := func(r *http.Request, err error) {
+		log.Printf("audioconnector: rejected connection from %s: %v", r.RemoteAddr, err)
+	}
+
+	if opts != nil && opts.OnError != nil {
+		onError = opts.OnError
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Accept(w, r)
+		if err != nil {
+			onError(r, err)
+			return
+		}
+		defer conn.Close()
+
+		fn(conn)
+	})
+}
+
+// Read reads the PCM audio. Text messages sent after the initial message are
+// skipped. It returns io.EOF when OpenTok closes the connection.
+func (c *Conn) Read(p []byte) (int, error) {
+	for {
+		if c.reader != nil {
+			n, err := c.reader.Read(p)
+			if err == io.EOF {
+				c.reader = nil
+				if n > 0 {
+					return n, nil
+				}
+
+				continue
+			}
+
+			return n, err
+		}
+
+		messageType, reader, err := c.ws.NextReader()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return 0, io.EOF
+			}
+
+			return 0, err
+		}
+
+		if messageType == websocket.BinaryMessage {
+			c.reader = reader
+		}
+	}
+}
+
+// Close closes the WebSocket connection.
+func (c *Conn) Close() error {
+	return c.ws.Close()
+}
+
+// Send a close message with the error as the reason and close the
+// connection. The reason is truncated to fit in a control frame.
+func closeWithError(ws *websocket.Conn, code int, err error) {
+	reason := err.Error()
+	if len(reason) > 123 {
+		reason = reason[:123]
+	}
+
+	ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+	ws.Close()
+}
+
+// Parse the initial message.
+func parseHeader(data []byte) (*Header, error) {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("Invalid initial message: %w", err)
+	}
+
+	header := &Header{
+		Headers: map[string]string{},
+	}
+
+	for key, value := range fields {
+		s, ok := value.(string)
+		if !ok {
+			s = fmt.Sprint(value)
+		}
+
+		if key == "content-type" {
+			header.ContentType = s
+			continue
+		}
+
+		header.Headers[key] = s
+	}
+
+	if header.ContentType == "" {
+		return nil, fmt.Errorf("Invalid initial message: missing content-type")
+	}
+
+	for _, param := range strings.Split(header.ContentType, ";")[1:] {
+		param = strings.TrimSpace(param)
+		if strings.HasPrefix(param, "rate=") {
+			rate, err := strconv.Atoi(strings.TrimPrefix(param, "rate="))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid initial message: bad audio rate: %w", err)
+			}
+
+			header.SampleRate = rate
+		}
+	}
+
+	return header, nil
+}
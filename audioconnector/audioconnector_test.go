@@ -0,0 +1,103 @@
+package audioconnector
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	type result struct {
+		header *Header
+		audio  []byte
+		err    error
+	}
+
+	results := make(chan result, 1)
+
+	ts := httptest.NewServer(Handler(func(conn *Conn) {
+		audio, err := ioutil.ReadAll(conn)
+		results <- result{conn.Header, audio, err}
+	}, nil))
+	defer ts.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer ws.Close()
+
+	assert.Nil(t, ws.WriteMessage(websocket.TextMessage, []byte(`{"content-type": "audio/l16;rate=16000", "room": "lobby"}`)))
+	assert.Nil(t, ws.WriteMessage(websocket.BinaryMessage, []byte{0x01, 0x00, 0x02, 0x00}))
+	assert.Nil(t, ws.WriteMessage(websocket.TextMessage, []byte(`{"event": "websocket:dtmf"}`)))
+	assert.Nil(t, ws.WriteMessage(websocket.BinaryMessage, []byte{0x03, 0x00}))
+	assert.Nil(t, ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")))
+
+	actual := <-results
+
+	assert.Nil(t, actual.err)
+	assert.Equal(t, &Header{
+		ContentType: "audio/l16;rate=16000",
+		SampleRate:  16000,
+		Headers:     map[string]string{"room": "lobby"},
+	}, actual.header)
+	assert.Equal(t, []byte{0x01, 0x00, 0x02, 0x00, 0x03, 0x00}, actual.audio)
+}
+
+func TestHandler_InvalidHeader(t *testing.T) {
+	errs := make(chan error, 1)
+
+	ts := httptest.NewServer(Handler(func(conn *Conn) {
+		t.Error("the connection should be rejected")
+	}, &HandlerOptions{
+		OnError: func(r *http.Request, err error) {
+			errs <- err
+		},
+	}))
+	defer ts.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer ws.Close()
+
+	assert.Nil(t, ws.WriteMessage(websocket.TextMessage, []byte(`{"room": "lobby"}`)))
+
+	_, _, err = ws.ReadMessage()
+	if assert.True(t, websocket.IsCloseError(err, websocket.CloseProtocolError)) {
+		assert.Contains(t, err.Error(), "missing content-type")
+	}
+
+	assert.EqualError(t, <-errs, "Invalid initial message: missing content-type")
+}
+
+func TestHandler_NotWebSocket(t *testing.T) {
+	errs := make(chan error, 1)
+
+	ts := httptest.NewServer(Handler(func(conn *Conn) {}, &HandlerOptions{
+		OnError: func(r *http.Request, err error) {
+			errs <- err
+		},
+	}))
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL)
+	if assert.Nil(t, err) {
+		res.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	}
+
+	assert.NotNil(t, <-errs)
+}
+
+func TestParseHeader_MissingContentType(t *testing.T) {
+	_, err := parseHeader([]byte(`{"room": "lobby"}`))
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,25 @@
+package audioconnector_test
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/audioconnector"
+)
+
+func ExampleHandler() {
+	http.Handle("/audio", audioconnector.Handler(func(conn *audioconnector.Conn) {
+		log.Printf("receiving %d Hz audio for room %s", conn.Header.SampleRate, conn.Header.Headers["room"])
+
+		// Feed the 16-bit PCM audio to a transcription pipeline.
+		if _, err := io.Copy(ioutil.Discard, conn); err != nil {
+			log.Println(err)
+		}
+	}, &audioconnector.HandlerOptions{
+		OnError: func(r *http.Request, err error) {
+			log.Printf("rejected Audio Connector connection: %v", err)
+		},
+	}))
+}
@@ -5,5 +5,6 @@ go 1.13
 require (
 	github.com/golang-jwt/jwt/v4 v4.1.0
 	github.com/google/uuid v1.3.0
+	github.com/gorilla/websocket v1.5.0
 	github.com/stretchr/testify v1.7.0
 )
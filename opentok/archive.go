@@ -76,6 +76,12 @@ const (
 
 	// HDPortrait (720x1280-pixel) archives have a 9:16 aspect ratio.
 	HDPortrait Resolution = "720x1280"
+
+	// FHDLandscape (1920x1080-pixel) has a 16:9 aspect ratio.
+	FHDLandscape Resolution = "1920x1080"
+
+	// FHDPortrait (1080x1920-pixel) has a 9:16 aspect ratio.
+	FHDPortrait Resolution = "1080x1920"
 )
 
 // StorageType is the alias of string type.
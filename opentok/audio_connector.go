@@ -0,0 +1,148 @@
+package opentok
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// The audio sample rate of the Audio Connector, in Hz.
+const (
+	AudioRate8K  = 8000
+	AudioRate16K = 16000
+)
+
+// WebSocketOptions defines the WebSocket endpoint the audio is streamed to.
+type WebSocketOptions struct {
+	// The URI of the WebSocket server, starting with "ws://" or "wss://".
+	URI string `json:"uri"`
+
+	// The stream IDs to include. If empty, all streams in the session are
+	// included.
+	Streams []string `json:"streams,omitempty"`
+
+	// Custom headers sent in the initial message to the WebSocket server.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// The audio sample rate, either AudioRate8K (the default) or AudioRate16K.
+	AudioRate int `json:"audioRate,omitempty"`
+}
+
+// AudioConnectorOptions defines the options for connecting the audio of a
+// session to a WebSocket.
+type AudioConnectorOptions struct {
+	// The OpenTok session ID.
+	SessionID string `json:"sessionId"`
+
+	// The OpenTok token used by the Audio Connector to connect to the session.
+	Token string `json:"token"`
+
+	// The WebSocket information.
+	WebSocket *WebSocketOptions `json:"websocket"`
+
+	// The data for token generation
+	TokenData string `json:"-"`
+}
+
+// AudioConnector defines the response returned from API.
+type AudioConnector struct {
+	// A unique ID for the Audio Connector.
+	ID string `json:"id"`
+
+	// The OpenTok connection ID for the Audio Connector's connection in the
+	// OpenTok session.
+	ConnectionID string `json:"connectionId"`
+
+	// The OpenTok session ID.
+	SessionID string `json:"-"`
+
+	// The instance of OpenTok.
+	OpenTok *OpenTok `json:"-"`
+}
+
+// ConnectAudioToWebSocket streams the audio of an OpenTok session to a
+// WebSocket server as 16-bit linear PCM.
+func (ot *OpenTok) ConnectAudioToWebSocket(sessionID string, opts *AudioConnectorOptions) (*AudioConnector, error) {
+	return ot.ConnectAudioToWebSocketContext(context.Background(), sessionID, opts)
+}
+
+// ConnectAudioToWebSocketContext uses ctx for HTTP requests.
+func (ot *OpenTok) ConnectAudioToWebSocketContext(ctx context.Context, sessionID string, opts *AudioConnectorOptions) (*AudioConnector, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Audio cannot be connected to a WebSocket without a session ID")
+	}
+
+	if opts == nil || opts.WebSocket == nil || opts.WebSocket.URI == "" {
+		return nil, fmt.Errorf("Audio cannot be connected to a WebSocket without a WebSocket URI")
+	}
+
+	if opts.WebSocket.AudioRate != 0 && opts.WebSocket.AudioRate != AudioRate8K && opts.WebSocket.AudioRate != AudioRate16K {
+		return nil, fmt.Errorf("Invalid audio rate for connecting audio to a WebSocket")
+	}
+
+	token, err := ot.GenerateToken(sessionID, &TokenOptions{
+		Data: opts.TokenData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opts.SessionID = sessionID
+	opts.Token = token
+
+	jsonStr, _ := json.Marshal(opts)
+
+	// Create jwt token
+	jwt, err := ot.genProjectJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := ot.apiHost + projectURL + "/" + ot.apiKey + "/connect"
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer(jsonStr))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("X-OPENTOK-AUTH", jwt)
+
+	res, err := ot.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, parseErrorResponse(res)
+	}
+
+	audioConnector := &AudioConnector{}
+	if err := json.NewDecoder(res.Body).Decode(audioConnector); err != nil {
+		return nil, err
+	}
+
+	audioConnector.SessionID = sessionID
+	audioConnector.OpenTok = ot
+
+	return audioConnector, nil
+}
+
+// Disconnect stops the Audio Connector by disconnecting its connection from
+// the session.
+func (audioConnector *AudioConnector) Disconnect() error {
+	return audioConnector.OpenTok.ForceDisconnect(audioConnector.SessionID, audioConnector.ConnectionID)
+}
+
+// ConnectAudioToWebSocket streams the audio of the session to a WebSocket
+// server.
+func (s *Session) ConnectAudioToWebSocket(opts *AudioConnectorOptions) (*AudioConnector, error) {
+	return s.ConnectAudioToWebSocketContext(context.Background(), opts)
+}
+
+// ConnectAudioToWebSocketContext uses ctx for HTTP requests.
+func (s *Session) ConnectAudioToWebSocketContext(ctx context.Context, opts *AudioConnectorOptions) (*AudioConnector, error) {
+	return s.OpenTok.ConnectAudioToWebSocketContext(ctx, s.SessionID, opts)
+}
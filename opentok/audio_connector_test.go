@@ -0,0 +1,81 @@
+package opentok
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenTok_ConnectAudioToWebSocket(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			assert.True(t, strings.HasSuffix(r.URL.Path, "/connection/da9cb410-e29b-4c2d-ab9e-fe65bf83fcaf"))
+
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		body := struct {
+			Token     string            `json:"token"`
+			WebSocket *WebSocketOptions `json:"websocket"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.NotEmpty(t, body.Token)
+		assert.Equal(t, &WebSocketOptions{
+			URI:       "wss://example.com/audio",
+			Streams:   []string{"d962b966-964d-4f18-be3f-e0b181a43b0e"},
+			Headers:   map[string]string{"room": "lobby"},
+			AudioRate: AudioRate16K,
+		}, body.WebSocket)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`
+			{
+				"id": "b0a5a8c7-dc38-459f-a48d-a7f2008da853",
+				"connectionId": "da9cb410-e29b-4c2d-ab9e-fe65bf83fcaf"
+			}
+		`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	expect := &AudioConnector{
+		ID:           "b0a5a8c7-dc38-459f-a48d-a7f2008da853",
+		ConnectionID: "da9cb410-e29b-4c2d-ab9e-fe65bf83fcaf",
+		SessionID:    "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34",
+		OpenTok:      ot,
+	}
+
+	actual, err := ot.ConnectAudioToWebSocket("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &AudioConnectorOptions{
+		WebSocket: &WebSocketOptions{
+			URI:       "wss://example.com/audio",
+			Streams:   []string{"d962b966-964d-4f18-be3f-e0b181a43b0e"},
+			Headers:   map[string]string{"room": "lobby"},
+			AudioRate: AudioRate16K,
+		},
+	})
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, expect, actual)
+		assert.Nil(t, actual.Disconnect())
+	}
+}
+
+func TestOpenTok_ConnectAudioToWebSocket_InvalidAudioRate(t *testing.T) {
+	_, err := ot.ConnectAudioToWebSocket("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &AudioConnectorOptions{
+		WebSocket: &WebSocketOptions{
+			URI:       "wss://example.com/audio",
+			AudioRate: 44100,
+		},
+	})
+
+	assert.NotNil(t, err)
+}
@@ -4,14 +4,36 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 )
 
+// StreamMode is the alias of string type.
+type StreamMode string
+
+const (
+	// StreamModeAuto means all streams in the session are included in the
+	// broadcast, the default.
+	StreamModeAuto StreamMode = "auto"
+
+	// StreamModeManual means streams are included in the broadcast only after
+	// they are added by calling AddBroadcastStream.
+	StreamModeManual StreamMode = "manual"
+)
+
 // HLSConfig defines the config of HLS.
-type HLSConfig struct{}
+type HLSConfig struct {
+	// Whether to enable DVR functionality (rewinding, pausing, and resuming)
+	// in players that support it (true), or not (false, the default).
+	DVR bool `json:"dvr,omitempty"`
+
+	// Whether to enable low-latency mode for the HLS stream (true), or not
+	// (false, the default). Low-latency mode cannot be used with DVR.
+	LowLatency bool `json:"lowLatency,omitempty"`
+}
 
 // RTMPConfig defines the config of RTMP.
 type RTMPConfig struct {
@@ -58,6 +80,14 @@ type BroadcastOptions struct {
 
 	// The resolution of the broadcast: either SD(default) or HD.
 	Resolution Resolution `json:"resolution,omitempty"`
+
+	// Whether streams included in the broadcast are selected automatically
+	// (StreamModeAuto, the default) or manually (StreamModeManual).
+	StreamMode StreamMode `json:"streamMode,omitempty"`
+
+	// The tag to identify the broadcast when running multiple broadcasts of
+	// the same session at the same time.
+	MultiBroadcastTag string `json:"multiBroadcastTag,omitempty"`
 }
 
 // BroadcastURLs defines the details on the HLS and RTMP broadcast streams.
@@ -97,10 +127,41 @@ type Broadcast struct {
 	// An object containing details about the HLS and RTMP broadcasts.
 	BroadcastURLs *BroadcastURLs `json:"broadcastUrls"`
 
+	// The stream mode of the broadcast.
+	StreamMode StreamMode `json:"streamMode,omitempty"`
+
+	// The streams included in the broadcast.
+	Streams []*BroadcastStream `json:"streams,omitempty"`
+
+	// The tag of the broadcast, if any.
+	MultiBroadcastTag string `json:"multiBroadcastTag,omitempty"`
+
 	// The instance of OpenTok.
 	OpenTok *OpenTok `json:"-"`
 }
 
+// BroadcastStream defines a stream included in a broadcast.
+type BroadcastStream struct {
+	// The stream ID.
+	StreamID string `json:"streamId"`
+
+	// Whether the audio of the stream is included in the broadcast.
+	HasAudio bool `json:"hasAudio"`
+
+	// Whether the video of the stream is included in the broadcast.
+	HasVideo bool `json:"hasVideo"`
+}
+
+// BroadcastStreamOptions defines the options for adding a stream to a
+// broadcast.
+type BroadcastStreamOptions struct {
+	// Whether to include the audio of the stream in the broadcast.
+	HasAudio bool
+
+	// Whether to include the video of the stream in the broadcast.
+	HasVideo bool
+}
+
 // BroadcastListOptions defines the query parameters to filter the list of
 // broadcasts.
 type BroadcastListOptions struct {
@@ -159,6 +220,14 @@ func (ot *OpenTok) StartBroadcastContext(ctx context.Context, sessionID string,
 		if opts.Resolution != "" && opts.Resolution != SD && opts.Resolution != HD {
 			return nil, fmt.Errorf("Invalid resolution for starting a live streaming broadcast")
 		}
+
+		if opts.StreamMode != "" && opts.StreamMode != StreamModeAuto && opts.StreamMode != StreamModeManual {
+			return nil, fmt.Errorf("Invalid stream mode for starting a live streaming broadcast")
+		}
+
+		if opts.Outputs != nil && opts.Outputs.HLS != nil && opts.Outputs.HLS.DVR && opts.Outputs.HLS.LowLatency {
+			return nil, fmt.Errorf("DVR and low-latency mode cannot be both enabled for HLS")
+		}
 	}
 
 	jsonStr, _ := json.Marshal(opts)
@@ -198,6 +267,72 @@ func (ot *OpenTok) StartBroadcastContext(ctx context.Context, sessionID string,
 	return broadcast, nil
 }
 
+// StartOrGetBroadcast starts a live streaming broadcast for an OpenTok session
+// unless the session already has an active broadcast with the same
+// MultiBroadcastTag, in which case the existing broadcast is returned. It is
+// safe to retry after a timeout without creating duplicate broadcasts.
+func (ot *OpenTok) StartOrGetBroadcast(sessionID string, opts *BroadcastOptions) (*Broadcast, error) {
+	return ot.StartOrGetBroadcastContext(context.Background(), sessionID, opts)
+}
+
+// StartOrGetBroadcastContext uses ctx for HTTP requests.
+func (ot *OpenTok) StartOrGetBroadcastContext(ctx context.Context, sessionID string, opts *BroadcastOptions) (*Broadcast, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Live streaming broadcast cannot be started without a session ID")
+	}
+
+	tag := ""
+	if opts != nil {
+		tag = opts.MultiBroadcastTag
+	}
+
+	broadcast, err := ot.findActiveBroadcast(ctx, sessionID, tag)
+	if err != nil || broadcast != nil {
+		return broadcast, err
+	}
+
+	broadcast, err = ot.StartBroadcastContext(ctx, sessionID, opts)
+	if err == nil {
+		return broadcast, nil
+	}
+
+	// A conflict means that a previous attempt started the broadcast.
+	var resErr *ResponseError
+	if !errors.As(err, &resErr) || resErr.StatusCode != 409 {
+		return nil, err
+	}
+
+	existing, findErr := ot.findActiveBroadcast(ctx, sessionID, tag)
+	if findErr != nil {
+		return nil, findErr
+	}
+
+	if existing == nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Find the active broadcast with the tag for a session.
+func (ot *OpenTok) findActiveBroadcast(ctx context.Context, sessionID, tag string) (*Broadcast, error) {
+	broadcastList, err := ot.ListBroadcastsContext(ctx, &BroadcastListOptions{
+		Count:     1000,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, broadcast := range broadcastList.Items {
+		if broadcast.Status != "stopped" && broadcast.MultiBroadcastTag == tag {
+			return broadcast, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // StopBroadcast stops a live broadcast of an OpenTok session.
 // Note that broadcasts automatically stop 120 minutes after they are started.
 func (ot *OpenTok) StopBroadcast(broadcastID string) (*Broadcast, error) {
@@ -413,7 +548,101 @@ func (ot *OpenTok) SetBroadcastLayoutContext(ctx context.Context, broadcastID st
 	return broadcast, nil
 }
 
+// AddBroadcastStream adds a stream to a live streaming broadcast that uses
+// the manual stream mode. If opts is nil, both audio and video of the stream
+// are included.
+func (ot *OpenTok) AddBroadcastStream(broadcastID, streamID string, opts *BroadcastStreamOptions) error {
+	return ot.AddBroadcastStreamContext(context.Background(), broadcastID, streamID, opts)
+}
+
+// AddBroadcastStreamContext uses ctx for HTTP requests.
+func (ot *OpenTok) AddBroadcastStreamContext(ctx context.Context, broadcastID, streamID string, opts *BroadcastStreamOptions) error {
+	if streamID == "" {
+		return fmt.Errorf("Stream cannot be added to a live streaming broadcast without a stream ID")
+	}
+
+	if opts == nil {
+		opts = &BroadcastStreamOptions{
+			HasAudio: true,
+			HasVideo: true,
+		}
+	}
+
+	if !opts.HasAudio && !opts.HasVideo {
+		return fmt.Errorf("Stream cannot be added to a live streaming broadcast without audio or video")
+	}
+
+	return ot.patchBroadcastStreams(ctx, broadcastID, map[string]interface{}{
+		"addStream": streamID,
+		"hasAudio":  opts.HasAudio,
+		"hasVideo":  opts.HasVideo,
+	})
+}
+
+// RemoveBroadcastStream removes a stream from a live streaming broadcast that
+// uses the manual stream mode.
+func (ot *OpenTok) RemoveBroadcastStream(broadcastID, streamID string) error {
+	return ot.RemoveBroadcastStreamContext(context.Background(), broadcastID, streamID)
+}
+
+// RemoveBroadcastStreamContext uses ctx for HTTP requests.
+func (ot *OpenTok) RemoveBroadcastStreamContext(ctx context.Context, broadcastID, streamID string) error {
+	if streamID == "" {
+		return fmt.Errorf("Stream cannot be removed from a live streaming broadcast without a stream ID")
+	}
+
+	return ot.patchBroadcastStreams(ctx, broadcastID, map[string]interface{}{
+		"removeStream": streamID,
+	})
+}
+
+// Change the streams included in a live streaming broadcast.
+func (ot *OpenTok) patchBroadcastStreams(ctx context.Context, broadcastID string, body map[string]interface{}) error {
+	if broadcastID == "" {
+		return fmt.Errorf("Cannot change the streams of a live streaming broadcast without a broadcast ID")
+	}
+
+	jsonStr, _ := json.Marshal(body)
+
+	// Create jwt token
+	jwt, err := ot.genProjectJWT()
+	if err != nil {
+		return err
+	}
+
+	endpoint := ot.apiHost + projectURL + "/" + ot.apiKey + "/broadcast/" + broadcastID + "/streams"
+	req, err := http.NewRequest(http.MethodPatch, endpoint, bytes.NewBuffer(jsonStr))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("X-OPENTOK-AUTH", jwt)
+
+	res, err := ot.sendRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 204 {
+		return parseErrorResponse(res)
+	}
+
+	return nil
+}
+
 // Stop stops a live broadcast of an OpenTok session.
 func (broadcast *Broadcast) Stop() (*Broadcast, error) {
 	return broadcast.OpenTok.StopBroadcast(broadcast.ID)
 }
+
+// AddStream adds a stream to the broadcast.
+func (broadcast *Broadcast) AddStream(streamID string, opts *BroadcastStreamOptions) error {
+	return broadcast.OpenTok.AddBroadcastStream(broadcast.ID, streamID, opts)
+}
+
+// RemoveStream removes a stream from the broadcast.
+func (broadcast *Broadcast) RemoveStream(streamID string) error {
+	return broadcast.OpenTok.RemoveBroadcastStream(broadcast.ID, streamID)
+}
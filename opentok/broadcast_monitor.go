@@ -0,0 +1,336 @@
+package opentok
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// The status of an RTMP stream of a live streaming broadcast.
+const (
+	RTMPConnecting = "connecting"
+	RTMPLive       = "live"
+	RTMPOffline    = "offline"
+	RTMPError      = "error"
+)
+
+// BroadcastEventType is the alias of string type.
+type BroadcastEventType string
+
+const (
+	// BroadcastStatusChanged is emitted when the status of a broadcast changes.
+	BroadcastStatusChanged BroadcastEventType = "broadcastStatusChanged"
+
+	// RTMPStatusChanged is emitted when the status of an RTMP stream changes.
+	RTMPStatusChanged BroadcastEventType = "rtmpStatusChanged"
+
+	// RTMPStalled is emitted once when an RTMP stream stays in the connecting
+	// status longer than the connecting timeout.
+	RTMPStalled BroadcastEventType = "rtmpStalled"
+
+	// BroadcastAutoStopped is emitted when the monitor stops a broadcast.
+	BroadcastAutoStopped BroadcastEventType = "broadcastAutoStopped"
+
+	// BroadcastMonitorError is emitted when polling or stopping a broadcast
+	// fails.
+	BroadcastMonitorError BroadcastEventType = "error"
+)
+
+// BroadcastMonitorOptions defines the options for monitoring broadcasts.
+type BroadcastMonitorOptions struct {
+	// The polling interval, 10 seconds by default.
+	Interval time.Duration
+
+	// How long an RTMP stream may stay in the connecting status before it is
+	// reported as stalled. Zero disables stall detection.
+	ConnectingTimeout time.Duration
+
+	// Broadcasts running longer than this are stopped. Zero disables it.
+	MaxDuration time.Duration
+
+	// Whether to stop broadcasts whose RTMP streams are all offline.
+	StopWhenOffline bool
+}
+
+// BroadcastEvent defines an event emitted by the broadcast monitor.
+type BroadcastEvent struct {
+	// The type of the event.
+	Type BroadcastEventType
+
+	// The broadcast ID.
+	BroadcastID string
+
+	// The RTMP stream ID, for RTMP events.
+	RTMPID string
+
+	// The previous status of the broadcast or RTMP stream.
+	PreviousStatus string
+
+	// The current status of the broadcast or RTMP stream.
+	Status string
+
+	// The latest broadcast information, if any.
+	Broadcast *Broadcast
+
+	// The error, for error events.
+	Err error
+
+	// The time at which the event was detected.
+	Time time.Time
+}
+
+// BroadcastMonitor polls live streaming broadcasts and emits events when
+// their status or the status of their RTMP streams changes.
+type BroadcastMonitor struct {
+	ot   *OpenTok
+	opts BroadcastMonitorOptions
+
+	mu         sync.Mutex
+	broadcasts map[string]*broadcastState
+
+	events chan *BroadcastEvent
+}
+
+// Tracked state of a broadcast between polls.
+type broadcastState struct {
+	status          string
+	rtmpStatus      map[string]string
+	connectingSince map[string]time.Time
+	stalled         map[string]bool
+}
+
+// NewBroadcastMonitor returns a broadcast monitor. Call Run to start polling.
+func (ot *OpenTok) NewBroadcastMonitor(opts *BroadcastMonitorOptions) *BroadcastMonitor {
+	m := &BroadcastMonitor{
+		ot:         ot,
+		broadcasts: map[string]*broadcastState{},
+		events:     make(chan *BroadcastEvent, 16),
+	}
+
+	if opts != nil {
+		m.opts = *opts
+	}
+
+	if m.opts.Interval <= 0 {
+		m.opts.Interval = 10 * time.Second
+	}
+
+	return m
+}
+
+// Add starts monitoring a broadcast.
+func (m *BroadcastMonitor) Add(broadcastID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.broadcasts[broadcastID]; !ok {
+		m.broadcasts[broadcastID] = &broadcastState{
+			rtmpStatus:      map[string]string{},
+			connectingSince: map[string]time.Time{},
+			stalled:         map[string]bool{},
+		}
+	}
+}
+
+// Remove stops monitoring a broadcast.
+func (m *BroadcastMonitor) Remove(broadcastID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.broadcasts, broadcastID)
+}
+
+// Events returns the channel on which events are emitted. It is closed when
+// Run returns.
+func (m *BroadcastMonitor) Events() <-chan *BroadcastEvent {
+	return m.events
+}
+
+// Run polls the monitored broadcasts at the configured interval until ctx is
+// done. Broadcasts are no longer monitored once they have stopped.
+func (m *BroadcastMonitor) Run(ctx context.Context) error {
+	defer close(m.events)
+
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.poll(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll all monitored broadcasts once.
+func (m *BroadcastMonitor) poll(ctx context.Context) error {
+	m.mu.Lock()
+	ids := make([]string, 0, len(m.broadcasts))
+	for id := range m.broadcasts {
+		ids = append(ids, id)
+	}
+	m.mu.Unlock()
+
+	for _, id := range ids {
+		if err := m.check(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Poll a broadcast and emit events for its changes. It only returns an error
+// if ctx is done.
+func (m *BroadcastMonitor) check(ctx context.Context, broadcastID string) error {
+	broadcast, err := m.ot.GetBroadcastContext(ctx, broadcastID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// The broadcast no longer exists.
+		var resErr *ResponseError
+		if errors.As(err, &resErr) && resErr.StatusCode == 404 {
+			m.Remove(broadcastID)
+		}
+
+		return m.emit(ctx, &BroadcastEvent{Type: BroadcastMonitorError, BroadcastID: broadcastID, Err: err})
+	}
+
+	now := time.Now()
+	events := []*BroadcastEvent{}
+
+	m.mu.Lock()
+	state, ok := m.broadcasts[broadcastID]
+	if !ok {
+		m.mu.Unlock()
+		return nil
+	}
+
+	if broadcast.Status != state.status {
+		events = append(events, &BroadcastEvent{
+			Type:           BroadcastStatusChanged,
+			PreviousStatus: state.status,
+			Status:         broadcast.Status,
+		})
+		state.status = broadcast.Status
+	}
+
+	rtmps := []*RTMPConfig{}
+	if broadcast.BroadcastURLs != nil {
+		rtmps = broadcast.BroadcastURLs.RTMP
+	}
+
+	for _, rtmp := range rtmps {
+		previous := state.rtmpStatus[rtmp.ID]
+		if rtmp.Status != previous {
+			events = append(events, &BroadcastEvent{
+				Type:           RTMPStatusChanged,
+				RTMPID:         rtmp.ID,
+				PreviousStatus: previous,
+				Status:         rtmp.Status,
+			})
+			state.rtmpStatus[rtmp.ID] = rtmp.Status
+
+			if rtmp.Status == RTMPConnecting {
+				state.connectingSince[rtmp.ID] = now
+			} else {
+				delete(state.connectingSince, rtmp.ID)
+				delete(state.stalled, rtmp.ID)
+			}
+		}
+
+		if since, ok := state.connectingSince[rtmp.ID]; ok && m.opts.ConnectingTimeout > 0 &&
+			!state.stalled[rtmp.ID] && now.Sub(since) >= m.opts.ConnectingTimeout {
+			events = append(events, &BroadcastEvent{
+				Type:   RTMPStalled,
+				RTMPID: rtmp.ID,
+				Status: rtmp.Status,
+			})
+			state.stalled[rtmp.ID] = true
+		}
+	}
+
+	if broadcast.Status == "stopped" {
+		delete(m.broadcasts, broadcastID)
+	}
+	m.mu.Unlock()
+
+	for _, event := range events {
+		event.BroadcastID = broadcastID
+		event.Broadcast = broadcast
+		event.Time = now
+
+		if err := m.emit(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	if broadcast.Status != "started" || !m.shouldStop(broadcast, now) {
+		return nil
+	}
+
+	stopped, err := m.ot.StopBroadcastContext(ctx, broadcastID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		return m.emit(ctx, &BroadcastEvent{Type: BroadcastMonitorError, BroadcastID: broadcastID, Broadcast: broadcast, Err: err, Time: now})
+	}
+
+	m.Remove(broadcastID)
+
+	return m.emit(ctx, &BroadcastEvent{
+		Type:           BroadcastAutoStopped,
+		BroadcastID:    broadcastID,
+		PreviousStatus: broadcast.Status,
+		Status:         stopped.Status,
+		Broadcast:      stopped,
+		Time:           now,
+	})
+}
+
+// Report whether the broadcast exceeds the maximum duration or has all RTMP
+// streams offline.
+func (m *BroadcastMonitor) shouldStop(broadcast *Broadcast, now time.Time) bool {
+	if m.opts.MaxDuration > 0 && broadcast.CreatedAt > 0 {
+		createdAt := time.Unix(0, int64(broadcast.CreatedAt)*int64(time.Millisecond))
+		if now.Sub(createdAt) >= m.opts.MaxDuration {
+			return true
+		}
+	}
+
+	if m.opts.StopWhenOffline && broadcast.BroadcastURLs != nil && len(broadcast.BroadcastURLs.RTMP) > 0 {
+		for _, rtmp := range broadcast.BroadcastURLs.RTMP {
+			if rtmp.Status != RTMPOffline {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Send an event unless ctx is done.
+func (m *BroadcastMonitor) emit(ctx context.Context, event *BroadcastEvent) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	select {
+	case m.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
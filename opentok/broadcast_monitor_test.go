@@ -0,0 +1,108 @@
+package opentok
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcastMonitor_Run(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/stop") {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id": "ce872e0d-4997-440a-a0a5-10ce715b54cf", "status": "stopped"}`))
+			return
+		}
+
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		mu.Lock()
+		polls++
+		rtmpStatus := "connecting"
+		if polls >= 3 {
+			rtmpStatus = "offline"
+		}
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`
+			{
+				"id": "ce872e0d-4997-440a-a0a5-10ce715b54cf",
+				"status": "started",
+				"broadcastUrls": {
+					"rtmp": [{
+						"id": "foo",
+						"status": "` + rtmpStatus + `",
+						"serverUrl": "rtmps://myfooserver/myfooapp",
+						"streamName": "myfoostream"
+					}]
+				}
+			}
+		`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	monitor := ot.NewBroadcastMonitor(&BroadcastMonitorOptions{
+		Interval:          10 * time.Millisecond,
+		ConnectingTimeout: time.Nanosecond,
+		StopWhenOffline:   true,
+	})
+	monitor.Add("ce872e0d-4997-440a-a0a5-10ce715b54cf")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		done <- monitor.Run(ctx)
+	}()
+
+	types := []BroadcastEventType{}
+	for event := range monitor.Events() {
+		assert.Equal(t, "ce872e0d-4997-440a-a0a5-10ce715b54cf", event.BroadcastID)
+		types = append(types, event.Type)
+
+		if event.Type == BroadcastAutoStopped {
+			assert.Equal(t, "stopped", event.Status)
+			cancel()
+		}
+	}
+
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Equal(t, []BroadcastEventType{
+		BroadcastStatusChanged,
+		RTMPStatusChanged,
+		RTMPStalled,
+		RTMPStatusChanged,
+		BroadcastAutoStopped,
+	}, types)
+}
+
+func TestBroadcastMonitor_ShouldStop(t *testing.T) {
+	monitor := ot.NewBroadcastMonitor(&BroadcastMonitorOptions{
+		MaxDuration: time.Hour,
+	})
+
+	now := time.Now()
+	broadcast := &Broadcast{
+		CreatedAt: int(now.Add(-2*time.Hour).UnixNano() / int64(time.Millisecond)),
+	}
+
+	assert.True(t, monitor.shouldStop(broadcast, now))
+
+	broadcast.CreatedAt = int(now.UnixNano() / int64(time.Millisecond))
+
+	assert.False(t, monitor.shouldStop(broadcast, now))
+}
@@ -1,6 +1,7 @@
 package opentok
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -380,3 +381,160 @@ func TestBroadcast_Stop(t *testing.T) {
 		assert.Equal(t, expect, actual)
 	}
 }
+
+func TestOpenTok_StartBroadcast_HLSDVRWithLowLatency(t *testing.T) {
+	_, err := ot.StartBroadcast("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &BroadcastOptions{
+		Outputs: &BroadcastOutputOptions{
+			HLS: &HLSConfig{
+				DVR:        true,
+				LowLatency: true,
+			},
+		},
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestOpenTok_GetBroadcast_ManualStreamMode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`
+			{
+				"id": "ce872e0d-4997-440a-a0a5-10ce715b54cf",
+				"sessionId": "1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4",
+				"projectId": 40000001,
+				"createdAt": 1579163008000,
+				"updatedAt": 1579163008000,
+				"resolution": "1280x720",
+				"status": "started",
+				"broadcastUrls": null,
+				"streamMode": "manual",
+				"streams": [{
+					"streamId": "d962b966-964d-4f18-be3f-e0b181a43b0e",
+					"hasAudio": true,
+					"hasVideo": false
+				}]
+			}
+		`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	actual, err := ot.GetBroadcast("ce872e0d-4997-440a-a0a5-10ce715b54cf")
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, StreamModeManual, actual.StreamMode)
+		assert.Equal(t, []*BroadcastStream{
+			{
+				StreamID: "d962b966-964d-4f18-be3f-e0b181a43b0e",
+				HasAudio: true,
+				HasVideo: false,
+			},
+		}, actual.Streams)
+	}
+}
+
+func TestOpenTok_AddBroadcastStream(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/v2/project/"+apiKey+"/broadcast/ce872e0d-4997-440a-a0a5-10ce715b54cf/streams", r.URL.Path)
+
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"addStream": "d962b966-964d-4f18-be3f-e0b181a43b0e", "hasAudio": true, "hasVideo": false}`, string(body))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	err := ot.AddBroadcastStream("ce872e0d-4997-440a-a0a5-10ce715b54cf", "d962b966-964d-4f18-be3f-e0b181a43b0e", &BroadcastStreamOptions{
+		HasAudio: true,
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestOpenTok_RemoveBroadcastStream(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"removeStream": "d962b966-964d-4f18-be3f-e0b181a43b0e"}`, string(body))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	err := ot.RemoveBroadcastStream("ce872e0d-4997-440a-a0a5-10ce715b54cf", "d962b966-964d-4f18-be3f-e0b181a43b0e")
+
+	assert.Nil(t, err)
+}
+
+func TestOpenTok_StartOrGetBroadcast(t *testing.T) {
+	started := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", r.URL.Query().Get("sessionId"))
+
+			w.WriteHeader(http.StatusOK)
+			if !started {
+				w.Write([]byte(`{"count": 0, "items": []}`))
+				return
+			}
+
+			w.Write([]byte(`
+				{
+					"count": 2,
+					"items": [{
+						"id": "a0e1b2c3-0000-4000-8000-000000000000",
+						"status": "started",
+						"multiBroadcastTag": "other"
+					}, {
+						"id": "ce872e0d-4997-440a-a0a5-10ce715b54cf",
+						"status": "started",
+						"multiBroadcastTag": "public"
+					}]
+				}
+			`))
+		case http.MethodPost:
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"multiBroadcastTag":"public"`)
+
+			// Simulate a request that started the broadcast but timed out.
+			started = true
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message": "Broadcast already started for this session"}`))
+		}
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	actual, err := ot.StartOrGetBroadcast("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &BroadcastOptions{
+		MultiBroadcastTag: "public",
+	})
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, "ce872e0d-4997-440a-a0a5-10ce715b54cf", actual.ID)
+		assert.Equal(t, "public", actual.MultiBroadcastTag)
+	}
+
+	// A retry returns the existing broadcast without starting a new one.
+	actual, err = ot.StartOrGetBroadcast("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &BroadcastOptions{
+		MultiBroadcastTag: "public",
+	})
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, "ce872e0d-4997-440a-a0a5-10ce715b54cf", actual.ID)
+	}
+}
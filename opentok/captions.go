@@ -0,0 +1,241 @@
+package opentok
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// CaptionsStatus is the alias of string type.
+type CaptionsStatus string
+
+const (
+	// CaptionsStarted means the captions have started.
+	CaptionsStarted CaptionsStatus = "started"
+
+	// CaptionsPaused means no audio is being transcribed, because no stream
+	// publishes audio.
+	CaptionsPaused CaptionsStatus = "paused"
+
+	// CaptionsStopped means the captions have stopped.
+	CaptionsStopped CaptionsStatus = "stopped"
+
+	// CaptionsFailed means the captions have failed.
+	CaptionsFailed CaptionsStatus = "failed"
+)
+
+// CaptionsOptions defines the options for starting live captions.
+type CaptionsOptions struct {
+	// The OpenTok session ID to caption.
+	SessionID string `json:"sessionId"`
+
+	// The OpenTok token used to connect to the session.
+	Token string `json:"token"`
+
+	// The BCP-47 code for the spoken language, "en-US" by default.
+	LanguageCode string `json:"languageCode,omitempty"`
+
+	// The maximum duration for the captions, in seconds.
+	MaxDuration int `json:"maxDuration,omitempty"`
+
+	// Whether to only send final captions. By default, captions are also sent
+	// as they are being transcribed.
+	DisablePartialCaptions bool `json:"-"`
+
+	// The URL to receive the status callbacks of the captions.
+	StatusCallbackURL string `json:"statusCallbackUrl,omitempty"`
+}
+
+// Captions defines the response returned from API.
+type Captions struct {
+	// The unique ID for the captions.
+	ID string `json:"captionsId"`
+
+	// The instance of OpenTok.
+	OpenTok *OpenTok `json:"-"`
+}
+
+// CaptionsStatusCallback defines the status callback sent by OpenTok to the
+// status callback URL of the captions.
+type CaptionsStatusCallback struct {
+	// The unique ID for the captions.
+	CaptionsID string `json:"captionId"`
+
+	// The API key associated with the captions.
+	ApplicationID string `json:"applicationId"`
+
+	// The OpenTok session ID.
+	SessionID string `json:"sessionId"`
+
+	// The status of the captions.
+	Status CaptionsStatus `json:"status"`
+
+	// The time at which the captions were created, in milliseconds since the
+	// UNIX epoch.
+	CreatedAt int `json:"createdAt"`
+
+	// The time at which the captions were updated, in milliseconds since the
+	// UNIX epoch.
+	UpdatedAt int `json:"updatedAt"`
+
+	// The duration of the captions, in seconds.
+	Duration int `json:"duration"`
+
+	// The spoken language of the captions.
+	LanguageCode string `json:"languageCode"`
+
+	// The transcription provider.
+	Provider string `json:"provider"`
+
+	// The reason the captions stopped or failed.
+	Reason string `json:"reason"`
+}
+
+// StartCaptions starts real-time live captions for an OpenTok session.
+func (ot *OpenTok) StartCaptions(sessionID string, opts *CaptionsOptions) (*Captions, error) {
+	return ot.StartCaptionsContext(context.Background(), sessionID, opts)
+}
+
+// StartCaptionsContext uses ctx for HTTP requests.
+func (ot *OpenTok) StartCaptionsContext(ctx context.Context, sessionID string, opts *CaptionsOptions) (*Captions, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Captions cannot be started without a session ID")
+	}
+
+	if opts == nil {
+		opts = &CaptionsOptions{}
+	}
+
+	if opts.MaxDuration != 0 && (opts.MaxDuration < 300 || opts.MaxDuration > 14400) {
+		return nil, fmt.Errorf("Invalid max duration for starting captions, must be between 300 and 14400 seconds")
+	}
+
+	// The captions service connects to the session as a moderator.
+	token, err := ot.GenerateToken(sessionID, &TokenOptions{
+		Role: Moderator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opts.SessionID = sessionID
+	opts.Token = token
+
+	// Only send partialCaptions to turn them off, so that the server default
+	// applies otherwise.
+	body := struct {
+		*CaptionsOptions
+		PartialCaptions *bool `json:"partialCaptions,omitempty"`
+	}{CaptionsOptions: opts}
+
+	if opts.DisablePartialCaptions {
+		partialCaptions := false
+		body.PartialCaptions = &partialCaptions
+	}
+
+	jsonStr, _ := json.Marshal(body)
+
+	// Create jwt token
+	jwt, err := ot.genProjectJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := ot.apiHost + projectURL + "/" + ot.apiKey + "/captions"
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer(jsonStr))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("X-OPENTOK-AUTH", jwt)
+
+	res, err := ot.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 202 {
+		return nil, parseErrorResponse(res)
+	}
+
+	captions := &Captions{}
+	if err := json.NewDecoder(res.Body).Decode(captions); err != nil {
+		return nil, err
+	}
+
+	captions.OpenTok = ot
+
+	return captions, nil
+}
+
+// StopCaptions stops live captions.
+func (ot *OpenTok) StopCaptions(captionsID string) error {
+	return ot.StopCaptionsContext(context.Background(), captionsID)
+}
+
+// StopCaptionsContext uses ctx for HTTP requests.
+func (ot *OpenTok) StopCaptionsContext(ctx context.Context, captionsID string) error {
+	if captionsID == "" {
+		return fmt.Errorf("Captions cannot be stopped without a captions ID")
+	}
+
+	// Create jwt token
+	jwt, err := ot.genProjectJWT()
+	if err != nil {
+		return err
+	}
+
+	endpoint := ot.apiHost + projectURL + "/" + ot.apiKey + "/captions/" + captionsID + "/stop"
+	req, err := http.NewRequest(http.MethodPost, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("X-OPENTOK-AUTH", jwt)
+
+	res, err := ot.sendRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 202 {
+		return parseErrorResponse(res)
+	}
+
+	return nil
+}
+
+// ParseCaptionsStatusCallback decodes the body of a captions status callback.
+func ParseCaptionsStatusCallback(r io.Reader) (*CaptionsStatusCallback, error) {
+	callback := &CaptionsStatusCallback{}
+	if err := json.NewDecoder(r).Decode(callback); err != nil {
+		return nil, err
+	}
+
+	if callback.CaptionsID == "" {
+		return nil, fmt.Errorf("Invalid captions status callback: missing captions ID")
+	}
+
+	return callback, nil
+}
+
+// Stop stops the live captions.
+func (captions *Captions) Stop() error {
+	return captions.OpenTok.StopCaptions(captions.ID)
+}
+
+// StartCaptions starts real-time live captions for the session.
+func (s *Session) StartCaptions(opts *CaptionsOptions) (*Captions, error) {
+	return s.StartCaptionsContext(context.Background(), opts)
+}
+
+// StartCaptionsContext uses ctx for HTTP requests.
+func (s *Session) StartCaptionsContext(ctx context.Context, opts *CaptionsOptions) (*Captions, error) {
+	return s.OpenTok.StartCaptionsContext(ctx, s.SessionID, opts)
+}
@@ -0,0 +1,117 @@
+package opentok
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenTok_StartCaptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		body := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "en-US", body["languageCode"])
+		assert.NotContains(t, body, "partialCaptions")
+		assert.Equal(t, "https://example.com/captions", body["statusCallbackUrl"])
+		assert.NotEmpty(t, body["token"])
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"captionsId": "7c0680fc-6274-4de5-a66f-d0648e8d3ac2"}`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	expect := &Captions{
+		ID:      "7c0680fc-6274-4de5-a66f-d0648e8d3ac2",
+		OpenTok: ot,
+	}
+
+	actual, err := ot.StartCaptions("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &CaptionsOptions{
+		LanguageCode:      "en-US",
+		MaxDuration:       1800,
+		StatusCallbackURL: "https://example.com/captions",
+	})
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, expect, actual)
+	}
+}
+
+func TestOpenTok_StartCaptions_DisablePartialCaptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, false, body["partialCaptions"])
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"captionsId": "7c0680fc-6274-4de5-a66f-d0648e8d3ac2"}`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	_, err := ot.StartCaptions("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &CaptionsOptions{
+		DisablePartialCaptions: true,
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestOpenTok_StopCaptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/captions/7c0680fc-6274-4de5-a66f-d0648e8d3ac2/stop"))
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	err := ot.StopCaptions("7c0680fc-6274-4de5-a66f-d0648e8d3ac2")
+
+	assert.Nil(t, err)
+}
+
+func TestParseCaptionsStatusCallback(t *testing.T) {
+	expect := &CaptionsStatusCallback{
+		CaptionsID:    "7c0680fc-6274-4de5-a66f-d0648e8d3ac2",
+		ApplicationID: "40000001",
+		SessionID:     "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34",
+		Status:        CaptionsStopped,
+		CreatedAt:     1651691290000,
+		UpdatedAt:     1651691300000,
+		Duration:      10,
+		LanguageCode:  "en-US",
+		Provider:      "aws-transcribe",
+		Reason:        "Session ended",
+	}
+
+	actual, err := ParseCaptionsStatusCallback(strings.NewReader(`
+		{
+			"captionId": "7c0680fc-6274-4de5-a66f-d0648e8d3ac2",
+			"applicationId": "40000001",
+			"sessionId": "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34",
+			"status": "stopped",
+			"createdAt": 1651691290000,
+			"updatedAt": 1651691300000,
+			"duration": 10,
+			"languageCode": "en-US",
+			"provider": "aws-transcribe",
+			"reason": "Session ended",
+			"group": "captions"
+		}
+	`))
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, expect, actual)
+	}
+}
@@ -0,0 +1,165 @@
+package opentok
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// The state of a connection.
+const (
+	ConnectionConnecting = "Connecting"
+	ConnectionConnected  = "Connected"
+)
+
+// Connection defines the response returned from API.
+type Connection struct {
+	// The connection ID.
+	ID string `json:"connectionId"`
+
+	// The state of the connection, either "Connecting" or "Connected".
+	State string `json:"connectionState"`
+
+	// The time at which the connection was created, in milliseconds since the
+	// UNIX epoch.
+	CreatedAt int `json:"createdAt"`
+
+	// The connection data set in the token of the client, if any.
+	Data string `json:"data,omitempty"`
+
+	// The session ID of the connection.
+	SessionID string `json:"-"`
+
+	// The instance of OpenTok.
+	OpenTok *OpenTok `json:"-"`
+}
+
+// ConnectionListOptions defines the query parameters to page through the
+// connections of a session.
+type ConnectionListOptions struct {
+	// Query parameters to specify the index offset of the first connection.
+	Offset int
+
+	// Query parameter to limit the number of connections to be returned.
+	Count int
+}
+
+// ConnectionList defines the response returned from API.
+type ConnectionList struct {
+	// The total number of connections in the session.
+	Count int `json:"count"`
+
+	// The API key associated with the project.
+	ProjectID string `json:"projectId"`
+
+	// The session ID.
+	SessionID string `json:"sessionId"`
+
+	// An array of objects defining each connection retrieved.
+	Items []*Connection `json:"items"`
+}
+
+// ListConnections returns the connections of a session.
+func (ot *OpenTok) ListConnections(sessionID string, opts *ConnectionListOptions) (*ConnectionList, error) {
+	return ot.ListConnectionsContext(context.Background(), sessionID, opts)
+}
+
+// ListConnectionsContext uses ctx for HTTP requests.
+func (ot *OpenTok) ListConnectionsContext(ctx context.Context, sessionID string, opts *ConnectionListOptions) (*ConnectionList, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Cannot list connections without a session ID")
+	}
+
+	params := []string{"?"}
+
+	if opts != nil {
+		if opts.Offset != 0 {
+			params = append(params, "offset="+strconv.Itoa(opts.Offset))
+		}
+
+		if opts.Count != 0 {
+			params = append(params, "count="+strconv.Itoa(opts.Count))
+		}
+	}
+
+	// Create jwt token
+	jwt, err := ot.genProjectJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := ot.apiHost + projectURL + "/" + ot.apiKey + "/session/" + sessionID + "/connection" + strings.Join(params, "&")
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("X-OPENTOK-AUTH", jwt)
+
+	res, err := ot.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, parseErrorResponse(res)
+	}
+
+	connectionList := &ConnectionList{}
+	if err := json.NewDecoder(res.Body).Decode(connectionList); err != nil {
+		return nil, err
+	}
+
+	for _, connection := range connectionList.Items {
+		connection.SessionID = sessionID
+		connection.OpenTok = ot
+	}
+
+	return connectionList, nil
+}
+
+// Disconnect disconnects the client from its session.
+func (connection *Connection) Disconnect() error {
+	return connection.DisconnectContext(context.Background())
+}
+
+// DisconnectContext uses ctx for HTTP requests.
+func (connection *Connection) DisconnectContext(ctx context.Context) error {
+	return connection.OpenTok.ForceDisconnectContext(ctx, connection.SessionID, connection.ID)
+}
+
+// Connections returns all connections of the session.
+func (s *Session) Connections() ([]*Connection, error) {
+	return s.ConnectionsContext(context.Background())
+}
+
+// ConnectionsContext uses ctx for HTTP requests.
+func (s *Session) ConnectionsContext(ctx context.Context) ([]*Connection, error) {
+	return s.OpenTok.listAllConnections(ctx, s.SessionID)
+}
+
+// Return all connections of a session, one page at a time.
+func (ot *OpenTok) listAllConnections(ctx context.Context, sessionID string) ([]*Connection, error) {
+	const pageSize = 1000
+
+	connections := []*Connection{}
+	for offset := 0; ; offset += pageSize {
+		page, err := ot.ListConnectionsContext(ctx, sessionID, &ConnectionListOptions{
+			Offset: offset,
+			Count:  pageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		connections = append(connections, page.Items...)
+
+		if len(page.Items) < pageSize || len(connections) >= page.Count {
+			return connections, nil
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package opentok
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// MaxConnectionDataLength is the maximum length of the connection data of a
+// token.
+const MaxConnectionDataLength = 1024
+
+// The connection data of SetSignedData.
+type signedConnectionData struct {
+	Data      json.RawMessage `json:"data"`
+	Signature string          `json:"sig"`
+}
+
+// SetData sets the connection data of the token to the compact JSON encoding
+// of v.
+func (opts *TokenOptions) SetData(v interface{}) error {
+	data, err := encodeConnectionData(v)
+	if err != nil {
+		return err
+	}
+
+	return opts.setData(data)
+}
+
+// SetSignedData sets the connection data of the token to the compact JSON
+// encoding of v, signed with the key, so that the data relayed by clients can
+// be checked with DecodeSignedConnectionData.
+func (opts *TokenOptions) SetSignedData(v interface{}, key []byte) error {
+	if len(key) == 0 {
+		return fmt.Errorf("Connection data cannot be signed without a key")
+	}
+
+	data, err := encodeConnectionData(v)
+	if err != nil {
+		return err
+	}
+
+	signed, err := encodeConnectionData(&signedConnectionData{
+		Data:      data,
+		Signature: signConnectionData(data, key),
+	})
+	if err != nil {
+		return err
+	}
+
+	return opts.setData(signed)
+}
+
+// DecodeConnectionData decodes the JSON connection data set by SetData into
+// v.
+func DecodeConnectionData(data string, v interface{}) error {
+	if data == "" {
+		return fmt.Errorf("Cannot decode empty connection data")
+	}
+
+	return json.Unmarshal([]byte(data), v)
+}
+
+// DecodeSignedConnectionData checks the signature of the connection data set
+// by SetSignedData and decodes it into v.
+func DecodeSignedConnectionData(data string, v interface{}, key []byte) error {
+	if len(key) == 0 {
+		return fmt.Errorf("Connection data cannot be checked without a key")
+	}
+
+	signed := &signedConnectionData{}
+	if err := DecodeConnectionData(data, signed); err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(signConnectionData(signed.Data, key)), []byte(signed.Signature)) {
+		return fmt.Errorf("Invalid connection data signature")
+	}
+
+	return json.Unmarshal(signed.Data, v)
+}
+
+// DecodeData decodes the JSON connection data of the connection into v.
+func (connection *Connection) DecodeData(v interface{}) error {
+	return DecodeConnectionData(connection.Data, v)
+}
+
+// Set the connection data if it fits.
+func (opts *TokenOptions) setData(data []byte) error {
+	if len(data) > MaxConnectionDataLength {
+		return fmt.Errorf("Invalid data for token generation, must be a string with maximum length %d", MaxConnectionDataLength)
+	}
+
+	opts.Data = string(data)
+
+	return nil
+}
+
+// Encode v in JSON without HTML escaping or trailing newline.
+func encodeConnectionData(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// Sign the connection data with HMAC-SHA256.
+func signConnectionData(data, key []byte) string {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,66 @@
+package opentok
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConnectionData struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+}
+
+func TestTokenOptions_SetData(t *testing.T) {
+	opts := &TokenOptions{}
+
+	assert.Nil(t, opts.SetData(&testConnectionData{UserID: "42", Name: "<Alice & Bob>"}))
+	assert.Equal(t, `{"userId":"42","name":"<Alice & Bob>"}`, opts.Data)
+
+	decoded := &testConnectionData{}
+	assert.Nil(t, DecodeConnectionData(opts.Data, decoded))
+	assert.Equal(t, &testConnectionData{UserID: "42", Name: "<Alice & Bob>"}, decoded)
+
+	err := opts.SetData(&testConnectionData{Name: strings.Repeat("a", MaxConnectionDataLength)})
+	assert.EqualError(t, err, "Invalid data for token generation, must be a string with maximum length 1024")
+
+	assert.NotNil(t, opts.SetData(func() {}))
+	assert.NotNil(t, DecodeConnectionData("", decoded))
+}
+
+func TestTokenOptions_SetSignedData(t *testing.T) {
+	key := []byte("connection data key")
+	opts := &TokenOptions{}
+
+	assert.Nil(t, opts.SetSignedData(&testConnectionData{UserID: "42"}, key))
+	assert.True(t, strings.HasPrefix(opts.Data, `{"data":{"userId":"42","name":""},"sig":"`))
+
+	decoded := &testConnectionData{}
+	assert.Nil(t, DecodeSignedConnectionData(opts.Data, decoded, key))
+	assert.Equal(t, "42", decoded.UserID)
+
+	forged := strings.Replace(opts.Data, `"userId":"42"`, `"userId":"1"`, 1)
+	assert.EqualError(t, DecodeSignedConnectionData(forged, decoded, key), "Invalid connection data signature")
+	assert.EqualError(t, DecodeSignedConnectionData(opts.Data, decoded, []byte("other key")), "Invalid connection data signature")
+
+	assert.NotNil(t, opts.SetSignedData(&testConnectionData{}, nil))
+}
+
+func TestDecodeSignedConnectionData_EmptyKey(t *testing.T) {
+	// Data signed with an empty key must not be accepted.
+	data := `{"data":{"userId":"1","name":""},"sig":"` + signConnectionData([]byte(`{"userId":"1","name":""}`), nil) + `"}`
+
+	decoded := &testConnectionData{}
+	assert.EqualError(t, DecodeSignedConnectionData(data, decoded, nil), "Connection data cannot be checked without a key")
+	assert.EqualError(t, DecodeSignedConnectionData(data, decoded, []byte{}), "Connection data cannot be checked without a key")
+	assert.Empty(t, decoded.UserID)
+}
+
+func TestConnection_DecodeData(t *testing.T) {
+	connection := &Connection{Data: `{"userId":"42","name":"Alice"}`}
+
+	decoded := &testConnectionData{}
+	assert.Nil(t, connection.DecodeData(decoded))
+	assert.Equal(t, &testConnectionData{UserID: "42", Name: "Alice"}, decoded)
+}
@@ -0,0 +1,116 @@
+package opentok
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenTok_ListConnections(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v2/project/"+apiKey+"/session/1_MX40NjYyMjI1Mn5-MTU5MDk0Mjg3MzQ0Mn5HZmI1cTV2ZlV4M2UrNzlTMGhrQ3VyNnZ-UH4/connection", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("offset"))
+		assert.Equal(t, "1", r.URL.Query().Get("count"))
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`
+			{
+				"count": 3,
+				"projectId": "` + apiKey + `",
+				"sessionId": "1_MX40NjYyMjI1Mn5-MTU5MDk0Mjg3MzQ0Mn5HZmI1cTV2ZlV4M2UrNzlTMGhrQ3VyNnZ-UH4",
+				"items": [
+					{
+						"connectionId": "e9f8c166-6c67-440d-994a-04fb6dfed007",
+						"connectionState": "Connected",
+						"createdAt": 1384221730555
+					}
+				]
+			}
+		`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	actual, err := ot.ListConnections("1_MX40NjYyMjI1Mn5-MTU5MDk0Mjg3MzQ0Mn5HZmI1cTV2ZlV4M2UrNzlTMGhrQ3VyNnZ-UH4", &ConnectionListOptions{
+		Offset: 2,
+		Count:  1,
+	})
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, &ConnectionList{
+			Count:     3,
+			ProjectID: apiKey,
+			SessionID: "1_MX40NjYyMjI1Mn5-MTU5MDk0Mjg3MzQ0Mn5HZmI1cTV2ZlV4M2UrNzlTMGhrQ3VyNnZ-UH4",
+			Items: []*Connection{
+				{
+					ID:        "e9f8c166-6c67-440d-994a-04fb6dfed007",
+					State:     ConnectionConnected,
+					CreatedAt: 1384221730555,
+					SessionID: "1_MX40NjYyMjI1Mn5-MTU5MDk0Mjg3MzQ0Mn5HZmI1cTV2ZlV4M2UrNzlTMGhrQ3VyNnZ-UH4",
+					OpenTok:   ot,
+				},
+			},
+		}, actual)
+	}
+}
+
+func TestSession_Connections(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		assert.Equal(t, "1000", r.URL.Query().Get("count"))
+
+		// Serve 1001 connections over two pages.
+		items := "["
+		for i := offset; i < 1001 && i < offset+1000; i++ {
+			if i > offset {
+				items += ","
+			}
+			items += `{"connectionId": "connection-` + strconv.Itoa(i) + `", "connectionState": "Connected", "createdAt": 1384221730555}`
+		}
+		items += "]"
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 1001, "items": ` + items + `}`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	session := &Session{
+		SessionID: "1_MX40NjYyMjI1Mn5-MTU5MDk0Mjg3MzQ0Mn5HZmI1cTV2ZlV4M2UrNzlTMGhrQ3VyNnZ-UH4",
+		OpenTok:   ot,
+	}
+
+	connections, err := session.Connections()
+
+	assert.Nil(t, err)
+
+	if assert.Len(t, connections, 1001) {
+		assert.Equal(t, "connection-0", connections[0].ID)
+		assert.Equal(t, "connection-1000", connections[1000].ID)
+	}
+}
+
+func TestConnection_Disconnect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/v2/project/"+apiKey+"/session/40000001/connection/e9f8c166-6c67-440d-994a-04fb6dfed007", r.URL.Path)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	connection := &Connection{
+		ID:        "e9f8c166-6c67-440d-994a-04fb6dfed007",
+		SessionID: "40000001",
+		OpenTok:   ot,
+	}
+
+	assert.Nil(t, connection.Disconnect())
+}
@@ -3,7 +3,7 @@
 //
 // It also includes methods for working with OpenTok archives, working with
 // OpenTok live streaming broadcasts, working with OpenTok SIP interconnect,
-// and disconnecting clients from sessions.
+// working with Experience Composers, and disconnecting clients from sessions.
 //
 // See Also
 //
@@ -17,5 +17,7 @@
 //
 // SIP interconnect: https://tokbox.com/developer/guides/sip
 //
+// Experience Composer: https://tokbox.com/developer/guides/experience-composer
+//
 // Disconnecting clients from sessions: https://tokbox.com/developer/guides/moderation/rest
 package opentok
@@ -0,0 +1,202 @@
+package opentok
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxDTMFLength is the maximum number of characters sent in a single DTMF
+// request.
+const MaxDTMFLength = 256
+
+// DTMFSequence is a sequence of DTMF tones: the digits 0-9, "*", "#", and "p"
+// for a 500 ms pause.
+type DTMFSequence string
+
+// String returns the sequence as a string.
+func (seq DTMFSequence) String() string {
+	return string(seq)
+}
+
+// Validate checks that the sequence is non-empty, within MaxDTMFLength, and
+// only contains valid characters.
+func (seq DTMFSequence) Validate() error {
+	if seq == "" {
+		return fmt.Errorf("The DTMF digits cannot be empty")
+	}
+
+	if len(seq) > MaxDTMFLength {
+		return fmt.Errorf("The DTMF digits cannot be longer than %d characters", MaxDTMFLength)
+	}
+
+	for _, r := range seq {
+		if !isDTMFChar(r) {
+			return fmt.Errorf("The DTMF digits is invalid")
+		}
+	}
+
+	return nil
+}
+
+// Chunks splits the sequence into chunks of at most size characters.
+func (seq DTMFSequence) Chunks(size int) []DTMFSequence {
+	if size <= 0 {
+		size = MaxDTMFLength
+	}
+
+	chunks := []DTMFSequence{}
+	for len(seq) > size {
+		chunks = append(chunks, seq[:size])
+		seq = seq[size:]
+	}
+
+	if seq != "" {
+		chunks = append(chunks, seq)
+	}
+
+	return chunks
+}
+
+// DTMFBuilder builds a DTMFSequence.
+type DTMFBuilder struct {
+	sb  strings.Builder
+	err error
+}
+
+// NewDTMFBuilder returns an empty DTMF builder.
+func NewDTMFBuilder() *DTMFBuilder {
+	return &DTMFBuilder{}
+}
+
+// Digits appends digits, "*" and "#" characters.
+func (b *DTMFBuilder) Digits(digits string) *DTMFBuilder {
+	for _, r := range digits {
+		if !isDTMFChar(r) || r == 'p' {
+			if b.err == nil {
+				b.err = fmt.Errorf("Invalid DTMF digit %q", r)
+			}
+
+			return b
+		}
+	}
+
+	b.sb.WriteString(digits)
+
+	return b
+}
+
+// Star appends a "*".
+func (b *DTMFBuilder) Star() *DTMFBuilder {
+	b.sb.WriteByte('*')
+
+	return b
+}
+
+// Pound appends a "#".
+func (b *DTMFBuilder) Pound() *DTMFBuilder {
+	b.sb.WriteByte('#')
+
+	return b
+}
+
+// Pause appends pauses for at least d, in steps of 500 ms.
+func (b *DTMFBuilder) Pause(d time.Duration) *DTMFBuilder {
+	const step = 500 * time.Millisecond
+
+	for n := (d + step - 1) / step; n > 0; n-- {
+		b.sb.WriteByte('p')
+	}
+
+	return b
+}
+
+// Build returns the sequence, or the first error found while building it.
+func (b *DTMFBuilder) Build() (DTMFSequence, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	seq := DTMFSequence(b.sb.String())
+	if err := seq.Validate(); err != nil {
+		return "", err
+	}
+
+	return seq, nil
+}
+
+// DTMFPacingOptions defines how a long DTMF sequence is split and paced.
+type DTMFPacingOptions struct {
+	// The maximum number of characters per request, 16 by default.
+	ChunkSize int
+
+	// The delay between requests, 1 second by default.
+	Interval time.Duration
+}
+
+// SendDTMFPaced sends a long DTMF sequence as paced chunks. If connectionID is
+// empty, the tones are sent to all clients connected to the session.
+func (ot *OpenTok) SendDTMFPaced(sessionID, connectionID string, digits DTMFSequence, opts *DTMFPacingOptions) error {
+	return ot.SendDTMFPacedContext(context.Background(), sessionID, connectionID, digits, opts)
+}
+
+// SendDTMFPacedContext uses ctx for HTTP requests and stops sending chunks
+// when ctx is done.
+func (ot *OpenTok) SendDTMFPacedContext(ctx context.Context, sessionID, connectionID string, digits DTMFSequence, opts *DTMFPacingOptions) error {
+	pacing := DTMFPacingOptions{
+		ChunkSize: 16,
+		Interval:  time.Second,
+	}
+
+	if opts != nil {
+		if opts.ChunkSize > 0 {
+			pacing.ChunkSize = opts.ChunkSize
+		}
+
+		if opts.Interval > 0 {
+			pacing.Interval = opts.Interval
+		}
+	}
+
+	if digits == "" {
+		return fmt.Errorf("The DTMF digits cannot be empty")
+	}
+
+	chunks := digits.Chunks(pacing.ChunkSize)
+	for _, chunk := range chunks {
+		if err := chunk.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for i, chunk := range chunks {
+		if i > 0 {
+			timer := time.NewTimer(pacing.Interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		var err error
+		if connectionID == "" {
+			err = ot.SendDTMFContext(ctx, sessionID, string(chunk))
+		} else {
+			err = ot.SendDTMFToClientContext(ctx, sessionID, connectionID, string(chunk))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Report whether the character is a valid DTMF character.
+func isDTMFChar(r rune) bool {
+	return (r >= '0' && r <= '9') || r == '*' || r == '#' || r == 'p'
+}
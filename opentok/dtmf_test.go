@@ -0,0 +1,91 @@
+package opentok
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDTMFBuilder(t *testing.T) {
+	actual, err := NewDTMFBuilder().Digits("1234").Pound().Pause(time.Second).Star().Digits("9").Build()
+
+	assert.Nil(t, err)
+	assert.Equal(t, DTMFSequence("1234#pp*9"), actual)
+	assert.Equal(t, "1234#pp*9", actual.String())
+
+	_, err = NewDTMFBuilder().Digits("12a").Build()
+
+	assert.NotNil(t, err)
+
+	_, err = NewDTMFBuilder().Build()
+
+	assert.NotNil(t, err)
+}
+
+func TestDTMFSequence_Validate(t *testing.T) {
+	assert.Nil(t, DTMFSequence("1713#p*").Validate())
+	assert.NotNil(t, DTMFSequence("").Validate())
+	assert.NotNil(t, DTMFSequence(`1" }`).Validate())
+	assert.NotNil(t, DTMFSequence(strings.Repeat("1", MaxDTMFLength+1)).Validate())
+}
+
+func TestDTMFSequence_Chunks(t *testing.T) {
+	expect := []DTMFSequence{"123", "456", "7"}
+
+	actual := DTMFSequence("1234567").Chunks(3)
+
+	assert.Equal(t, expect, actual)
+}
+
+func TestOpenTok_SendDTMFPaced(t *testing.T) {
+	bodies := []string{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/connection/3a6aa409-bfc5-462c-a9c7-59b72aeebf69/play-dtmf"))
+
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	err := ot.SendDTMFPaced("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", "3a6aa409-bfc5-462c-a9c7-59b72aeebf69", "123456#", &DTMFPacingOptions{
+		ChunkSize: 4,
+		Interval:  time.Millisecond,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`{"digits":"1234"}`, `{"digits":"56#"}`}, bodies)
+}
+
+func TestOpenTok_SendDTMFPaced_Canceled(t *testing.T) {
+	requests := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := ot.SendDTMFPacedContext(ctx, "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", "", "123456", &DTMFPacingOptions{
+		ChunkSize: 2,
+		Interval:  time.Hour,
+	})
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, requests)
+}
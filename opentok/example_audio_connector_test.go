@@ -0,0 +1,28 @@
+package opentok_test
+
+import (
+	"fmt"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_ConnectAudioToWebSocket() {
+	audioConnector, err := ot.ConnectAudioToWebSocket("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &opentok.AudioConnectorOptions{
+		WebSocket: &opentok.WebSocketOptions{
+			URI:       "wss://example.com/audio",
+			Headers:   map[string]string{"room": "lobby"},
+			AudioRate: opentok.AudioRate16K,
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Printf("%#v", audioConnector)
+	}
+
+	// &opentok.AudioConnector{
+	// 	ID:           "b0a5a8c7-dc38-459f-a48d-a7f2008da853",
+	// 	ConnectionID: "da9cb410-e29b-4c2d-ab9e-fe65bf83fcaf",
+	// 	SessionID:    "1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4",
+	// }
+}
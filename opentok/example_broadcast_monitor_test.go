@@ -0,0 +1,32 @@
+package opentok_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_NewBroadcastMonitor() {
+	monitor := ot.NewBroadcastMonitor(&opentok.BroadcastMonitorOptions{
+		Interval:          15 * time.Second,
+		ConnectingTimeout: time.Minute,
+		MaxDuration:       2 * time.Hour,
+		StopWhenOffline:   true,
+	})
+	monitor.Add("ce872e0d-4997-440a-a0a5-10ce715b54cf")
+
+	go monitor.Run(context.Background())
+
+	for event := range monitor.Events() {
+		switch event.Type {
+		case opentok.RTMPStalled:
+			fmt.Printf("RTMP stream %s is stuck connecting\n", event.RTMPID)
+		case opentok.RTMPStatusChanged:
+			fmt.Printf("RTMP stream %s: %s -> %s\n", event.RTMPID, event.PreviousStatus, event.Status)
+		case opentok.BroadcastMonitorError:
+			fmt.Println(event.Err)
+		}
+	}
+}
@@ -180,3 +180,34 @@ func ExampleOpenTok_SetBroadcastLayout() {
 	// 	},
 	// }
 }
+
+func ExampleOpenTok_AddBroadcastStream() {
+	err := ot.AddBroadcastStream("ce872e0d-4997-440a-a0a5-10ce715b54cf", "d962b966-964d-4f18-be3f-e0b181a43b0e", &opentok.BroadcastStreamOptions{
+		HasAudio: true,
+		HasVideo: true,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func ExampleOpenTok_RemoveBroadcastStream() {
+	err := ot.RemoveBroadcastStream("ce872e0d-4997-440a-a0a5-10ce715b54cf", "d962b966-964d-4f18-be3f-e0b181a43b0e")
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func ExampleOpenTok_StartOrGetBroadcast() {
+	broadcast, err := ot.StartOrGetBroadcast("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &opentok.BroadcastOptions{
+		Outputs: &opentok.BroadcastOutputOptions{
+			HLS: &opentok.HLSConfig{},
+		},
+		MultiBroadcastTag: "public",
+	})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(broadcast.ID)
+	}
+}
@@ -0,0 +1,40 @@
+package opentok_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_StartCaptions() {
+	captions, err := ot.StartCaptions("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &opentok.CaptionsOptions{
+		LanguageCode:      "en-US",
+		MaxDuration:       1800,
+		StatusCallbackURL: "https://example.com/captions",
+	})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(captions.ID)
+	}
+}
+
+func ExampleOpenTok_StopCaptions() {
+	err := ot.StopCaptions("7c0680fc-6274-4de5-a66f-d0648e8d3ac2")
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func ExampleParseCaptionsStatusCallback() {
+	http.HandleFunc("/captions", func(w http.ResponseWriter, r *http.Request) {
+		callback, err := opentok.ParseCaptionsStatusCallback(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Println(callback.CaptionsID, callback.Status)
+	})
+}
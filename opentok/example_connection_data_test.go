@@ -0,0 +1,49 @@
+package opentok_test
+
+import (
+	"fmt"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+type participant struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+}
+
+func ExampleTokenOptions_SetData() {
+	opts := &opentok.TokenOptions{
+		Role: opentok.Publisher,
+	}
+
+	if err := opts.SetData(&participant{UserID: "42", Name: "Alice"}); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(opts.Data)
+
+	// Output: {"userId":"42","name":"Alice"}
+}
+
+func ExampleDecodeSignedConnectionData() {
+	key := []byte("connection data key")
+
+	opts := &opentok.TokenOptions{}
+	if err := opts.SetSignedData(&participant{UserID: "42", Name: "Alice"}, key); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// The connection data relayed by a client cannot be changed without
+	// breaking the signature.
+	p := &participant{}
+	if err := opentok.DecodeSignedConnectionData(opts.Data, p, key); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(p.UserID, p.Name)
+
+	// Output: 42 Alice
+}
@@ -0,0 +1,43 @@
+package opentok_test
+
+import (
+	"fmt"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_ListConnections() {
+	connections, err := ot.ListConnections("1_MX40NjYyMjI1Mn5-MTU5MDk0Mjg3MzQ0Mn5HZmI1cTV2ZlV4M2UrNzlTMGhrQ3VyNnZ-UH4", &opentok.ConnectionListOptions{
+		Offset: 0,
+		Count:  100,
+	})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		for _, connection := range connections.Items {
+			fmt.Println(connection.ID, connection.State, connection.CreatedAt)
+		}
+	}
+}
+
+func ExampleSession_Connections() {
+	session, err := ot.CreateSession(&opentok.SessionOptions{
+		MediaMode: opentok.Routed,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	connections, err := session.Connections()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, connection := range connections {
+		if connection.State == opentok.ConnectionConnecting {
+			connection.Disconnect()
+		}
+	}
+}
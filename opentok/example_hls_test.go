@@ -0,0 +1,16 @@
+package opentok_test
+
+import (
+	"fmt"
+)
+
+func ExampleOpenTok_GetHLSPlaylist() {
+	playlist, err := ot.GetHLSPlaylist("https://cdn-broadcast001-pdx.tokbox.com/14935/14935_b1dc5e66-8fa2-4d5f-a0cc-7b3c0e1e9c43.smil/playlist.m3u8")
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		for _, variant := range playlist.Variants {
+			fmt.Println(variant.Resolution, variant.Media.TargetDuration, variant.Media.Latency())
+		}
+	}
+}
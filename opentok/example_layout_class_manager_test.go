@@ -0,0 +1,22 @@
+package opentok_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_NewLayoutClassManager() {
+	manager := ot.NewLayoutClassManager("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &opentok.LayoutClassManagerOptions{
+		OnError: func(err error) {
+			fmt.Println(err)
+		},
+	})
+
+	go manager.Run(context.Background())
+
+	// Called whenever the active speaker changes.
+	manager.Focus("8b732909-0a06-46a2-8ea8-074e64d43422")
+	manager.AddClass("a919b531-bd0e-41fb-8ff0-cdc15684cc93", "sidebar")
+}
@@ -1,6 +1,11 @@
 package opentok_test
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
 
 func ExampleOpenTok_ForceDisconnect() {
 	err := ot.ForceDisconnect("40000001", "efdf2fc7-bd6e-4871-9c1d-531f7f6a9486")
@@ -8,3 +13,28 @@ func ExampleOpenTok_ForceDisconnect() {
 		fmt.Println(err)
 	}
 }
+
+func ExampleOpenTok_DisconnectAll() {
+	results, err := ot.DisconnectAll("40000001", "efdf2fc7-bd6e-4871-9c1d-531f7f6a9486")
+	if err != nil {
+		for connectionID, err := range results {
+			if err != nil {
+				fmt.Println(connectionID, err)
+			}
+		}
+	}
+}
+
+func ExampleOpenTok_MuteStreams() {
+	results, err := ot.MuteStreams("40000001", &opentok.StreamFilter{
+		VideoType:   "camera",
+		NamePattern: regexp.MustCompile(`^guest-`),
+	})
+	if err != nil {
+		for streamID, err := range results {
+			if err != nil {
+				fmt.Println(streamID, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package opentok_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_SetRateLimiter() {
+	ot.SetRateLimiter(&opentok.RateLimiterOptions{
+		Global: &opentok.RateLimit{Rate: 50, Burst: 10},
+		Classes: map[opentok.RateClass]*opentok.RateLimit{
+			opentok.RateClassSignal:     {Rate: 20},
+			opentok.RateClassModeration: {Rate: 5},
+		},
+		OnWait: func(class opentok.RateClass, wait time.Duration) {
+			fmt.Println(class, "call delayed by", wait)
+		},
+	})
+}
+
+func ExampleOpenTok_RateLimiterMetrics() {
+	for class, metrics := range ot.RateLimiterMetrics() {
+		fmt.Println(class, metrics.Requests, metrics.Delayed, metrics.TotalWait)
+	}
+}
@@ -0,0 +1,52 @@
+package opentok_test
+
+import (
+	"fmt"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_StartRender() {
+	render, err := ot.StartRender("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &opentok.RenderOptions{
+		URL:         "https://example.com/overlay",
+		MaxDuration: 1800,
+		Resolution:  opentok.HDLandscape,
+		Properties: &opentok.RenderProperties{
+			Name: "Overlay",
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Printf("%#v", render)
+	}
+
+	// &opentok.Render{
+	// 	ID:         "1248e7070b81464c9789f46ad10e7764",
+	// 	SessionID:  "1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4",
+	// 	ProjectID:  "40000001",
+	// 	CreatedAt:  1437676551000,
+	// 	UpdatedAt:  1437676551000,
+	// 	URL:        "https://example.com/overlay",
+	// 	Resolution: "1280x720",
+	// 	Status:     "starting",
+	// }
+}
+
+func ExampleOpenTok_StopRender() {
+	err := ot.StopRender("1248e7070b81464c9789f46ad10e7764")
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func ExampleOpenTok_ListRenders() {
+	renders, err := ot.ListRenders(&opentok.RenderListOptions{
+		Count: 50,
+	})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Printf("%#v", renders)
+	}
+}
@@ -0,0 +1,39 @@
+package opentok_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_NewRoomRegistry() {
+	store, err := opentok.NewFileRoomStore("/var/lib/myapp/rooms.json")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	registry := ot.NewRoomRegistry(&opentok.RoomRegistryOptions{
+		Store: store,
+		TTL:   24 * time.Hour,
+	})
+
+	// All participants joining "standup" get the same session.
+	room, err := registry.GetOrCreate(context.Background(), "standup", &opentok.SessionOptions{
+		MediaMode: opentok.Routed,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	token, err := ot.GenerateToken(room.SessionID, &opentok.TokenOptions{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(token)
+}
@@ -0,0 +1,34 @@
+package opentok_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_NewSessionPool() {
+	pool := ot.NewSessionPool(&opentok.SessionPoolOptions{
+		Size: 10,
+	})
+
+	routed := &opentok.SessionOptions{
+		MediaMode:   opentok.Routed,
+		ArchiveMode: opentok.ManualArchived,
+	}
+	pool.Warm(routed)
+
+	go pool.Run(context.Background())
+
+	// When a user starts a meeting.
+	session, err := pool.Acquire(context.Background(), routed)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(session.SessionID)
+
+	metrics := pool.Metrics()
+	fmt.Printf("hit rate: %.2f, refill errors: %d\n", metrics.HitRate(), metrics.RefillErrors)
+}
@@ -41,7 +41,7 @@ func ExampleOpenTok_GenerateToken() {
 }
 
 func ExampleOpenTok_MuteSession() {
-	project, err := ot.MuteSession("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &opentok.MuteOptions{
+	result, err := ot.MuteSession("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &opentok.MuteOptions{
 		Active: true,
 		ExcludedStreams: []string{
 			"a919b531-bd0e-41fb-8ff0-cdc15684cc93",
@@ -51,18 +51,34 @@ func ExampleOpenTok_MuteSession() {
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		fmt.Printf("%#v", project)
+		fmt.Println(result.Active, result.ExcludedStreams)
 	}
 
-	// &opentok.Project{
-	// 	ID:                     "40000001",
-	// 	Secret:                 "ba7816bf8f01cfea414140de5dae2223b00361a3",
-	// 	Status:                 "VALID",
-	// 	Name:                   "example",
-	// 	CreatedAt:              1579163008000,
-	// 	EnvironmentName:        "default",
-	// 	EnvironmentDescription: "Standard Environment",
-	// }
+	// true [a919b531-bd0e-41fb-8ff0-cdc15684cc93 7f6d8780-741a-4824-98da-16c1f5f1f043]
+}
+
+func ExampleOpenTok_DisableForceMute() {
+	_, err := ot.DisableForceMute("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4")
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func ExampleSession_ExcludeStreams() {
+	session, err := ot.CreateSession(&opentok.SessionOptions{
+		MediaMode: opentok.Routed,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Keep the presenter unmuted, then mute the audience.
+	session.ExcludeStreams("7f6d8780-741a-4824-98da-16c1f5f1f043")
+
+	if _, err := session.Mute(&opentok.MuteOptions{Active: true}); err != nil {
+		fmt.Println(err)
+	}
 }
 
 func ExampleOpenTok_MuteStream() {
@@ -83,3 +99,20 @@ func ExampleOpenTok_MuteStream() {
 	// 	EnvironmentDescription: "Standard Environment",
 	// }
 }
+
+func ExampleOpenTok_SessionFromID() {
+	// A session ID stored in a database.
+	session, err := ot.SessionFromID("1_MX40MDAwMDAwMX5-MTU3Nzg2NTYwMDAwMH54N2I0OE1RZ0RmK1lRRnFQUWg4dlZmT0t-QX4")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	token, err := session.GenerateToken(&opentok.TokenOptions{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(session.CreatedAt, token)
+}
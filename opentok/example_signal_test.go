@@ -25,3 +25,38 @@ func ExampleOpenTok_SendConnectionSignal() {
 		fmt.Println(err)
 	}
 }
+
+func ExampleOpenTok_SendJSONSignal() {
+	type chatMessage struct {
+		From string `json:"from"`
+		Text string `json:"text"`
+	}
+
+	err := ot.SendJSONSignal("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", "chat", &chatMessage{
+		From: "moderator",
+		Text: "The session will end in 5 minutes",
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+func ExampleOpenTok_SendSignalToConnections() {
+	results, err := ot.SendSignalToConnections("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", []string{
+		"18145975-97c8-4802-8975-fc8408d56d5c",
+		"3a6aa409-bfc5-462c-a9c7-59b72aeebf69",
+	}, &opentok.SignalData{
+		Type: "breakout",
+		Data: "room-2",
+	}, &opentok.FanOutOptions{
+		Concurrency:   5,
+		RatePerSecond: 20,
+	})
+	if err != nil {
+		for connectionID, err := range results {
+			if err != nil {
+				fmt.Println(connectionID, err)
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package opentok_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
 )
@@ -46,3 +47,67 @@ func ExampleOpenTok_SendDTMFToClient() {
 		fmt.Println(err)
 	}
 }
+
+func ExampleParseSIPURI() {
+	uri, err := opentok.ParseSIPURI("sip:user@sip.example.com:5061;transport=tls")
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(uri.User, uri.Host, uri.Port, uri.IsTLS())
+	}
+
+	// Output: user sip.example.com 5061 true
+}
+
+func ExampleOpenTok_NewSIPCallManager() {
+	manager := ot.NewSIPCallManager(&opentok.SIPCallManagerOptions{
+		MaxCallDuration: time.Hour,
+	})
+
+	call, err := manager.Dial("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &opentok.DialOptions{
+		SIP: &opentok.SIP{
+			URI: "sip:ivr@sip.example.com;transport=tls",
+		},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Enter a PIN on this call only, then hang up.
+	if err := manager.SendDTMF(call.ID, "1234#"); err != nil {
+		fmt.Println(err)
+	}
+
+	if err := manager.Hangup(call.ID); err != nil {
+		fmt.Println(err)
+	}
+}
+
+func ExampleNewDTMFBuilder() {
+	pin, err := opentok.NewDTMFBuilder().
+		Digits("8675309").
+		Pound().
+		Pause(2 * time.Second).
+		Digits("1234").
+		Pound().
+		Build()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(pin)
+
+	// Output: 8675309#pppp1234#
+}
+
+func ExampleOpenTok_SendDTMFPaced() {
+	err := ot.SendDTMFPaced("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", "3a6aa409-bfc5-462c-a9c7-59b72aeebf69", "8675309#pppp1234#", &opentok.DTMFPacingOptions{
+		ChunkSize: 8,
+		Interval:  time.Second,
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+}
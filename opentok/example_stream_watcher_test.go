@@ -0,0 +1,30 @@
+package opentok_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_NewStreamWatcher() {
+	watcher := ot.NewStreamWatcher("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &opentok.StreamWatcherOptions{
+		Interval: 2 * time.Second,
+	})
+
+	go watcher.Run(context.Background())
+
+	for event := range watcher.Events() {
+		switch event.Type {
+		case opentok.StreamCreated:
+			fmt.Printf("%s joined\n", event.Stream.Name)
+		case opentok.StreamDestroyed:
+			fmt.Printf("%s left\n", event.Stream.Name)
+		case opentok.StreamChanged:
+			fmt.Printf("%s changed: %v -> %v\n", event.Stream.Name, event.Previous.LayoutClassList, event.Stream.LayoutClassList)
+		case opentok.StreamWatcherError:
+			fmt.Println(event.Err)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package opentok_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/calvertyang/opentok-go-sdk/v2/opentok"
+)
+
+func ExampleOpenTok_NewTokenHandler() {
+	authorizer := opentok.AuthorizerFunc(func(r *http.Request) (*opentok.TokenGrant, error) {
+		userID := r.Header.Get("X-User-ID") // Set by your authentication middleware.
+		if userID == "" {
+			return nil, &opentok.AuthorizationError{StatusCode: http.StatusUnauthorized, Message: "Sign in first"}
+		}
+
+		return &opentok.TokenGrant{
+			UserID:    userID,
+			SessionID: r.URL.Query().Get("sessionId"),
+			Role:      opentok.Publisher,
+			TTL:       2 * time.Hour,
+			Data:      "userId=" + userID,
+		}, nil
+	})
+
+	http.Handle("/token", ot.NewTokenHandler(authorizer, &opentok.TokenHandlerOptions{
+		AllowedOrigins: []string{"https://app.example.com"},
+		RateLimit:      &opentok.RateLimit{Rate: 1, Burst: 5},
+	}))
+}
@@ -0,0 +1,134 @@
+package opentok
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FanOutOptions defines how requests to many targets are run.
+type FanOutOptions struct {
+	// The maximum number of requests in flight, 10 by default.
+	Concurrency int
+
+	// The maximum number of requests started per second. Zero means no limit.
+	RatePerSecond float64
+}
+
+// FanOutError reports the targets for which a request failed.
+type FanOutError struct {
+	// The errors by target ID.
+	Errors map[string]error
+
+	// The number of targets.
+	Total int
+}
+
+// Error returns a formatted error message.
+func (e *FanOutError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, id+": "+e.Errors[id].Error())
+	}
+
+	return fmt.Sprintf("%d of %d requests failed: %s", len(e.Errors), e.Total, strings.Join(msgs, "; "))
+}
+
+// Run fn for each ID with bounded concurrency and an optional rate limit.
+// The result maps each ID to its error, nil on success. The returned error is
+// a *FanOutError if any request failed.
+func fanOut(ctx context.Context, ids []string, opts *FanOutOptions, fn func(ctx context.Context, id string) error) (map[string]error, error) {
+	concurrency := 10
+	var interval time.Duration
+
+	if opts != nil {
+		if opts.Concurrency > 0 {
+			concurrency = opts.Concurrency
+		}
+
+		if opts.RatePerSecond > 0 {
+			interval = time.Duration(float64(time.Second) / opts.RatePerSecond)
+		}
+	}
+
+	// Remove duplicate IDs.
+	unique := make([]string, 0, len(ids))
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	var ticker *time.Ticker
+	if interval > 0 {
+		ticker = time.NewTicker(interval)
+		defer ticker.Stop()
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]error, len(unique))
+		sem     = make(chan struct{}, concurrency)
+	)
+
+	for i, id := range unique {
+		// The first request starts immediately.
+		if ticker != nil && i > 0 {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+			}
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if err := ctx.Err(); err != nil {
+			mu.Lock()
+			results[id] = err
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := fn(ctx, id)
+
+			mu.Lock()
+			results[id] = err
+			mu.Unlock()
+		}(id)
+	}
+
+	wg.Wait()
+
+	failed := map[string]error{}
+	for id, err := range results {
+		if err != nil {
+			failed[id] = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, &FanOutError{Errors: failed, Total: len(unique)}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,73 @@
+package opentok
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanOut(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxSeen  int
+	)
+
+	results, err := fanOut(context.Background(), []string{"a", "b", "c", "d", "a"}, &FanOutOptions{Concurrency: 2}, func(ctx context.Context, id string) error {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxSeen {
+			maxSeen = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		if id == "c" {
+			return fmt.Errorf("boom")
+		}
+
+		return nil
+	})
+
+	assert.Len(t, results, 4)
+	assert.Nil(t, results["a"])
+	assert.EqualError(t, results["c"], "boom")
+	assert.LessOrEqual(t, maxSeen, 2)
+
+	if assert.IsType(t, &FanOutError{}, err) {
+		assert.Equal(t, 4, err.(*FanOutError).Total)
+		assert.EqualError(t, err, "1 of 4 requests failed: c: boom")
+	}
+}
+
+func TestFanOut_RateLimit(t *testing.T) {
+	start := time.Now()
+
+	_, err := fanOut(context.Background(), []string{"a", "b", "c"}, &FanOutOptions{RatePerSecond: 50}, func(ctx context.Context, id string) error {
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) >= 40*time.Millisecond)
+}
+
+func TestFanOut_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := fanOut(ctx, []string{"a", "b"}, nil, func(ctx context.Context, id string) error {
+		return nil
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, context.Canceled, results["a"])
+}
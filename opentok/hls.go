@@ -0,0 +1,391 @@
+package opentok
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HLSSegment defines a media segment listed in an HLS media playlist.
+type HLSSegment struct {
+	// The absolute URL of the segment.
+	URL string
+
+	// The media sequence number of the segment.
+	Sequence int
+
+	// The duration of the segment.
+	Duration time.Duration
+
+	// The wall clock time of the first sample of the segment, as declared by
+	// the EXT-X-PROGRAM-DATE-TIME tag. It is zero if the tag is absent.
+	ProgramDateTime time.Time
+}
+
+// HLSMediaPlaylist defines an HLS media playlist.
+type HLSMediaPlaylist struct {
+	// The absolute URL of the media playlist.
+	URL string
+
+	// The maximum segment duration declared by the playlist.
+	TargetDuration time.Duration
+
+	// The media sequence number of the first segment.
+	MediaSequence int
+
+	// The playlist type, either "EVENT", "VOD" or empty for live playlists.
+	PlaylistType string
+
+	// Whether the playlist has ended (EXT-X-ENDLIST) or not.
+	Ended bool
+
+	// The media segments, from the oldest to the newest.
+	Segments []*HLSSegment
+}
+
+// HLSVariant defines a variant stream listed in an HLS master playlist.
+type HLSVariant struct {
+	// The absolute URL of the media playlist of the variant.
+	URL string
+
+	// The peak bit rate of the variant, in bits per second.
+	Bandwidth int
+
+	// The resolution of the variant, such as "1280x720".
+	Resolution string
+
+	// The codecs of the variant.
+	Codecs string
+
+	// The media playlist of the variant.
+	Media *HLSMediaPlaylist
+}
+
+// HLSPlaylist defines the master playlist of an HLS broadcast along with the
+// media playlists of its variants.
+type HLSPlaylist struct {
+	// The absolute URL of the master playlist.
+	URL string
+
+	// The variant streams. If the URL points directly to a media playlist,
+	// it contains a single variant for that playlist.
+	Variants []*HLSVariant
+}
+
+// Duration returns the total duration of the segments in the playlist.
+func (p *HLSMediaPlaylist) Duration() time.Duration {
+	var total time.Duration
+	for _, segment := range p.Segments {
+		total += segment.Duration
+	}
+
+	return total
+}
+
+// LiveEdge returns the wall clock time at the end of the newest segment.
+// It is zero if the playlist does not declare EXT-X-PROGRAM-DATE-TIME.
+func (p *HLSMediaPlaylist) LiveEdge() time.Time {
+	if len(p.Segments) == 0 {
+		return time.Time{}
+	}
+
+	last := p.Segments[len(p.Segments)-1]
+	if last.ProgramDateTime.IsZero() {
+		return time.Time{}
+	}
+
+	return last.ProgramDateTime.Add(last.Duration)
+}
+
+// Latency returns how far the newest segment lags behind live. It is zero if
+// the live edge is unknown.
+func (p *HLSMediaPlaylist) Latency() time.Duration {
+	edge := p.LiveEdge()
+	if edge.IsZero() {
+		return 0
+	}
+
+	return time.Since(edge)
+}
+
+// GetHLSPlaylist fetches and parses the master playlist at the HLS broadcast
+// URL and the media playlists of its variants.
+func (ot *OpenTok) GetHLSPlaylist(hlsURL string) (*HLSPlaylist, error) {
+	return ot.GetHLSPlaylistContext(context.Background(), hlsURL)
+}
+
+// GetHLSPlaylistContext uses ctx for HTTP requests.
+func (ot *OpenTok) GetHLSPlaylistContext(ctx context.Context, hlsURL string) (*HLSPlaylist, error) {
+	if hlsURL == "" {
+		return nil, fmt.Errorf("Cannot get HLS playlist without an HLS URL")
+	}
+
+	lines, err := ot.fetchHLSPlaylist(ctx, hlsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	playlist := &HLSPlaylist{
+		URL: hlsURL,
+	}
+
+	if !isHLSMasterPlaylist(lines) {
+		media, err := parseHLSMediaPlaylist(hlsURL, lines)
+		if err != nil {
+			return nil, err
+		}
+
+		playlist.Variants = []*HLSVariant{{URL: hlsURL, Media: media}}
+
+		return playlist, nil
+	}
+
+	if playlist.Variants, err = parseHLSMasterPlaylist(hlsURL, lines); err != nil {
+		return nil, err
+	}
+
+	for _, variant := range playlist.Variants {
+		lines, err := ot.fetchHLSPlaylist(ctx, variant.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		if variant.Media, err = parseHLSMediaPlaylist(variant.URL, lines); err != nil {
+			return nil, err
+		}
+	}
+
+	return playlist, nil
+}
+
+// HLSPlaylist fetches and parses the HLS playlist of the broadcast.
+func (broadcast *Broadcast) HLSPlaylist() (*HLSPlaylist, error) {
+	return broadcast.HLSPlaylistContext(context.Background())
+}
+
+// HLSPlaylistContext uses ctx for HTTP requests.
+func (broadcast *Broadcast) HLSPlaylistContext(ctx context.Context) (*HLSPlaylist, error) {
+	if broadcast.BroadcastURLs == nil || broadcast.BroadcastURLs.HLS == "" {
+		return nil, fmt.Errorf("The broadcast has no HLS URL")
+	}
+
+	return broadcast.OpenTok.GetHLSPlaylistContext(ctx, broadcast.BroadcastURLs.HLS)
+}
+
+// Fetch a playlist and split it into non-empty lines.
+func (ot *OpenTok) fetchHLSPlaylist(ctx context.Context, playlistURL string) ([]string, error) {
+	req, err := http.NewRequest(http.MethodGet, playlistURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ot.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("Error fetching HLS playlist: statusCode: %d", res.StatusCode)
+	}
+
+	lines := []string{}
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 || lines[0] != "#EXTM3U" {
+		return nil, fmt.Errorf("Invalid HLS playlist: missing #EXTM3U header")
+	}
+
+	return lines, nil
+}
+
+// Report whether the playlist lists variant streams.
+func isHLSMasterPlaylist(lines []string) bool {
+	for _, line := range lines {
+		if strings.HasPrefix(line, "#EXT-X-STREAM-INF:") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parse the variant streams of a master playlist.
+func parseHLSMasterPlaylist(playlistURL string, lines []string) ([]*HLSVariant, error) {
+	variants := []*HLSVariant{}
+
+	var current *HLSVariant
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "#EXT-X-STREAM-INF:"):
+			attrs := parseHLSAttributes(strings.TrimPrefix(line, "#EXT-X-STREAM-INF:"))
+			bandwidth, err := strconv.Atoi(attrs["BANDWIDTH"])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid HLS playlist: bad BANDWIDTH attribute: %w", err)
+			}
+
+			current = &HLSVariant{
+				Bandwidth:  bandwidth,
+				Resolution: attrs["RESOLUTION"],
+				Codecs:     attrs["CODECS"],
+			}
+		case strings.HasPrefix(line, "#"):
+			// Ignore other tags and comments.
+		case current != nil:
+			variantURL, err := resolveHLSURL(playlistURL, line)
+			if err != nil {
+				return nil, err
+			}
+
+			current.URL = variantURL
+			variants = append(variants, current)
+			current = nil
+		}
+	}
+
+	return variants, nil
+}
+
+// Parse the segments of a media playlist.
+func parseHLSMediaPlaylist(playlistURL string, lines []string) (*HLSMediaPlaylist, error) {
+	playlist := &HLSMediaPlaylist{
+		URL:      playlistURL,
+		Segments: []*HLSSegment{},
+	}
+
+	var (
+		duration        time.Duration
+		programDateTime time.Time
+		inSegment       bool
+	)
+
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "#EXT-X-TARGETDURATION:"):
+			seconds, err := strconv.Atoi(strings.TrimPrefix(line, "#EXT-X-TARGETDURATION:"))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid HLS playlist: bad EXT-X-TARGETDURATION tag: %w", err)
+			}
+
+			playlist.TargetDuration = time.Duration(seconds) * time.Second
+		case strings.HasPrefix(line, "#EXT-X-MEDIA-SEQUENCE:"):
+			sequence, err := strconv.Atoi(strings.TrimPrefix(line, "#EXT-X-MEDIA-SEQUENCE:"))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid HLS playlist: bad EXT-X-MEDIA-SEQUENCE tag: %w", err)
+			}
+
+			playlist.MediaSequence = sequence
+		case strings.HasPrefix(line, "#EXT-X-PLAYLIST-TYPE:"):
+			playlist.PlaylistType = strings.TrimPrefix(line, "#EXT-X-PLAYLIST-TYPE:")
+		case line == "#EXT-X-ENDLIST":
+			playlist.Ended = true
+		case strings.HasPrefix(line, "#EXT-X-PROGRAM-DATE-TIME:"):
+			t, err := time.Parse(time.RFC3339Nano, strings.TrimPrefix(line, "#EXT-X-PROGRAM-DATE-TIME:"))
+			if err != nil {
+				return nil, fmt.Errorf("Invalid HLS playlist: bad EXT-X-PROGRAM-DATE-TIME tag: %w", err)
+			}
+
+			programDateTime = t
+		case strings.HasPrefix(line, "#EXTINF:"):
+			value := strings.TrimPrefix(line, "#EXTINF:")
+			if i := strings.Index(value, ","); i >= 0 {
+				value = value[:i]
+			}
+
+			seconds, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid HLS playlist: bad EXTINF tag: %w", err)
+			}
+
+			duration = time.Duration(seconds * float64(time.Second))
+			inSegment = true
+		case strings.HasPrefix(line, "#"):
+			// Ignore other tags and comments.
+		case inSegment:
+			segmentURL, err := resolveHLSURL(playlistURL, line)
+			if err != nil {
+				return nil, err
+			}
+
+			playlist.Segments = append(playlist.Segments, &HLSSegment{
+				URL:             segmentURL,
+				Sequence:        playlist.MediaSequence + len(playlist.Segments),
+				Duration:        duration,
+				ProgramDateTime: programDateTime,
+			})
+
+			// The date time of the following segments is derived from this one
+			// unless another EXT-X-PROGRAM-DATE-TIME tag is present.
+			if !programDateTime.IsZero() {
+				programDateTime = programDateTime.Add(duration)
+			}
+
+			inSegment = false
+		}
+	}
+
+	return playlist, nil
+}
+
+// Parse an attribute list such as `BANDWIDTH=1280000,CODECS="avc1,mp4a"`.
+func parseHLSAttributes(s string) map[string]string {
+	attrs := map[string]string{}
+
+	for s != "" {
+		eq := strings.Index(s, "=")
+		if eq < 0 {
+			break
+		}
+
+		key := strings.TrimSpace(s[:eq])
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.Index(s[1:], `"`)
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else if comma := strings.Index(s, ","); comma >= 0 {
+			value, s = s[:comma], s[comma:]
+		} else {
+			value, s = s, ""
+		}
+
+		attrs[key] = value
+		s = strings.TrimPrefix(s, ",")
+	}
+
+	return attrs
+}
+
+// Resolve a playlist entry against the URL of the playlist.
+func resolveHLSURL(playlistURL, ref string) (string, error) {
+	base, err := url.Parse(playlistURL)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+
+	return base.ResolveReference(u).String(), nil
+}
@@ -0,0 +1,138 @@
+package opentok
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenTok_GetHLSPlaylist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case "/broadcast/master.m3u8":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-STREAM-INF:BANDWIDTH=2128000,RESOLUTION=1280x720,CODECS="avc1.4d401f,mp4a.40.2"
+720p/playlist.m3u8
+`))
+		case "/broadcast/720p/playlist.m3u8":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:4
+#EXT-X-MEDIA-SEQUENCE:120
+#EXT-X-PROGRAM-DATE-TIME:2020-01-01T00:00:00.000Z
+#EXTINF:4.000,
+segment120.ts
+#EXTINF:3.500,
+segment121.ts
+`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	expect := &HLSPlaylist{
+		URL: ts.URL + "/broadcast/master.m3u8",
+		Variants: []*HLSVariant{
+			{
+				URL:        ts.URL + "/broadcast/720p/playlist.m3u8",
+				Bandwidth:  2128000,
+				Resolution: "1280x720",
+				Codecs:     "avc1.4d401f,mp4a.40.2",
+				Media: &HLSMediaPlaylist{
+					URL:            ts.URL + "/broadcast/720p/playlist.m3u8",
+					TargetDuration: 4 * time.Second,
+					MediaSequence:  120,
+					Segments: []*HLSSegment{
+						{
+							URL:             ts.URL + "/broadcast/720p/segment120.ts",
+							Sequence:        120,
+							Duration:        4 * time.Second,
+							ProgramDateTime: start,
+						},
+						{
+							URL:             ts.URL + "/broadcast/720p/segment121.ts",
+							Sequence:        121,
+							Duration:        3500 * time.Millisecond,
+							ProgramDateTime: start.Add(4 * time.Second),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	actual, err := ot.GetHLSPlaylist(ts.URL + "/broadcast/master.m3u8")
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, expect, actual)
+
+		media := actual.Variants[0].Media
+		assert.Equal(t, 7500*time.Millisecond, media.Duration())
+		assert.Equal(t, start.Add(7500*time.Millisecond), media.LiveEdge())
+		assert.True(t, media.Latency() > 0)
+	}
+}
+
+func TestOpenTok_GetHLSPlaylist_MediaPlaylist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`#EXTM3U
+#EXT-X-TARGETDURATION:2
+#EXT-X-PLAYLIST-TYPE:EVENT
+#EXTINF:2.0,
+https://cdn.example.com/segment0.ts
+#EXT-X-ENDLIST
+`))
+	}))
+	defer ts.Close()
+
+	actual, err := ot.GetHLSPlaylist(ts.URL + "/playlist.m3u8")
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) && assert.Len(t, actual.Variants, 1) {
+		media := actual.Variants[0].Media
+		assert.Equal(t, "EVENT", media.PlaylistType)
+		assert.True(t, media.Ended)
+		assert.Equal(t, "https://cdn.example.com/segment0.ts", media.Segments[0].URL)
+		assert.True(t, media.LiveEdge().IsZero())
+		assert.Equal(t, time.Duration(0), media.Latency())
+	}
+}
+
+func TestOpenTok_GetHLSPlaylist_InvalidPlaylist(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`<html></html>`))
+	}))
+	defer ts.Close()
+
+	_, err := ot.GetHLSPlaylist(ts.URL + "/playlist.m3u8")
+
+	assert.NotNil(t, err)
+}
+
+func TestParseHLSAttributes(t *testing.T) {
+	expect := map[string]string{
+		"BANDWIDTH":  "1280000",
+		"CODECS":     "avc1.4d401f,mp4a.40.2",
+		"RESOLUTION": "640x360",
+	}
+
+	actual := parseHLSAttributes(`BANDWIDTH=1280000,CODECS="avc1.4d401f,mp4a.40.2",RESOLUTION=640x360`)
+
+	assert.Equal(t, expect, actual)
+}
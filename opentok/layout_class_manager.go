@@ -0,0 +1,330 @@
+package opentok
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FocusClass is the layout class set by LayoutClassManager.Focus.
+const FocusClass = "focus"
+
+// LayoutClassManagerOptions defines the options for managing the layout
+// classes of the streams in a session.
+type LayoutClassManagerOptions struct {
+	// How long changes are collected before they are applied, 500 milliseconds
+	// by default.
+	BatchInterval time.Duration
+
+	// The number of retries of a failed update, 3 by default.
+	MaxRetries int
+
+	// The delay before the first retry, doubled after each retry, 1 second by
+	// default.
+	RetryDelay time.Duration
+
+	// How long the desired classes of a stream that is not listed in the
+	// session are kept, 1 minute by default. A stream that has just been
+	// published may not be listed yet, so its classes are applied once it is.
+	ForgetAfter time.Duration
+
+	// OnError is called when an update still fails after all retries. The
+	// update is tried again after the retry delay.
+	OnError func(err error)
+}
+
+// LayoutClassManager holds the desired layout classes of the streams in a
+// session and applies them. It only changes the classes it is told about, so
+// services managing different classes of the same streams do not overwrite
+// each other.
+type LayoutClassManager struct {
+	ot        *OpenTok
+	sessionID string
+	opts      LayoutClassManagerOptions
+
+	mu sync.Mutex
+
+	// Whether each stream must have (true) or must not have (false) a class.
+	desired map[string]map[string]bool
+
+	// The stream with the focus class, if any.
+	focused  string
+	focusSet bool
+
+	// When each stream was last listed or changed, for the streams that
+	// have desired classes or the focus.
+	seen map[string]time.Time
+
+	// Whether the last flush failed or left changes for unlisted streams.
+	pending bool
+
+	wake chan struct{}
+}
+
+// NewLayoutClassManager returns a layout class manager for a session. Call
+// Run to apply the changes in the background, or Flush to apply them now.
+func (ot *OpenTok) NewLayoutClassManager(sessionID string, opts *LayoutClassManagerOptions) *LayoutClassManager {
+	m := &LayoutClassManager{
+		ot:        ot,
+		sessionID: sessionID,
+		desired:   map[string]map[string]bool{},
+		seen:      map[string]time.Time{},
+		wake:      make(chan struct{}, 1),
+	}
+
+	if opts != nil {
+		m.opts = *opts
+	}
+
+	if m.opts.BatchInterval <= 0 {
+		m.opts.BatchInterval = 500 * time.Millisecond
+	}
+
+	if m.opts.MaxRetries <= 0 {
+		m.opts.MaxRetries = 3
+	}
+
+	if m.opts.RetryDelay <= 0 {
+		m.opts.RetryDelay = time.Second
+	}
+
+	if m.opts.ForgetAfter <= 0 {
+		m.opts.ForgetAfter = time.Minute
+	}
+
+	return m
+}
+
+// AddClass makes the stream have the layout class.
+func (m *LayoutClassManager) AddClass(streamID, class string) {
+	m.set(streamID, class, true)
+}
+
+// RemoveClass makes the stream not have the layout class.
+func (m *LayoutClassManager) RemoveClass(streamID, class string) {
+	m.set(streamID, class, false)
+}
+
+// Focus gives the focus class to the stream and removes it from all other
+// streams of the session. Pass an empty stream ID to remove it from all
+// streams.
+func (m *LayoutClassManager) Focus(streamID string) {
+	m.mu.Lock()
+	m.focused = streamID
+	m.focusSet = true
+	if streamID != "" {
+		m.seen[streamID] = time.Now()
+	}
+	m.mu.Unlock()
+
+	m.notify()
+}
+
+// Forget drops the desired classes of a stream, for example when it is
+// destroyed, and removes the focus from it.
+func (m *LayoutClassManager) Forget(streamID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.desired, streamID)
+	delete(m.seen, streamID)
+
+	if m.focused == streamID {
+		m.focused = ""
+	}
+}
+
+// Flush applies the desired layout classes now. It lists the streams of the
+// session and updates the streams whose classes differ from the desired ones.
+// The streams that are not listed are kept for ForgetAfter, and updated once
+// they are listed.
+func (m *LayoutClassManager) Flush(ctx context.Context) error {
+	streams, err := m.ot.ListStreamsContext(ctx, m.sessionID)
+	if err == nil {
+		if items := m.diff(streams); len(items) > 0 {
+			_, err = m.ot.SetStreamClassListsContext(ctx, m.sessionID, &StreamClassOptions{Items: items})
+		}
+	}
+
+	if err != nil {
+		m.mu.Lock()
+		m.pending = true
+		m.mu.Unlock()
+	}
+
+	return err
+}
+
+// Run applies the changes in batches until ctx is done, retrying failed
+// updates and the changes of the streams that are not listed yet.
+func (m *LayoutClassManager) Run(ctx context.Context) error {
+	for {
+		m.mu.Lock()
+		pending := m.pending
+		m.mu.Unlock()
+
+		var (
+			timer *time.Timer
+			retry <-chan time.Time
+		)
+
+		if pending {
+			timer = time.NewTimer(m.opts.RetryDelay)
+			retry = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-m.wake:
+		case <-retry:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// Collect the changes made in the meantime.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.opts.BatchInterval):
+		}
+
+		if err := m.flushWithRetry(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if m.opts.OnError != nil {
+				m.opts.OnError(err)
+			}
+		}
+	}
+}
+
+// Record the desired state of a class of a stream.
+func (m *LayoutClassManager) set(streamID, class string, present bool) {
+	m.mu.Lock()
+	classes, ok := m.desired[streamID]
+	if !ok {
+		classes = map[string]bool{}
+		m.desired[streamID] = classes
+	}
+	classes[class] = present
+	m.seen[streamID] = time.Now()
+	m.mu.Unlock()
+
+	m.notify()
+}
+
+// Wake up Run, unless it is already due to run.
+func (m *LayoutClassManager) notify() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Flush, retrying with an exponential backoff.
+func (m *LayoutClassManager) flushWithRetry(ctx context.Context) error {
+	delay := m.opts.RetryDelay
+
+	var err error
+	for attempt := 0; attempt <= m.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+
+			delay *= 2
+		}
+
+		if err = m.Flush(ctx); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// Compute the class lists of the streams whose classes differ from the
+// desired ones, and forget the streams that have not been listed for
+// ForgetAfter.
+func (m *LayoutClassManager) diff(streams *StreamList) []*StreamClass {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	present := map[string]bool{}
+	items := []*StreamClass{}
+
+	for _, stream := range streams.Items {
+		present[stream.ID] = true
+		if _, ok := m.seen[stream.ID]; ok {
+			m.seen[stream.ID] = now
+		}
+
+		wanted := map[string]bool{}
+		for class, has := range m.desired[stream.ID] {
+			wanted[class] = has
+		}
+
+		if m.focusSet {
+			wanted[FocusClass] = stream.ID == m.focused
+		}
+
+		if len(wanted) == 0 {
+			continue
+		}
+
+		current := map[string]bool{}
+		target := []string{}
+		for _, class := range stream.LayoutClassList {
+			current[class] = true
+			if has, ok := wanted[class]; !ok || has {
+				target = append(target, class)
+			}
+		}
+
+		added := []string{}
+		for class, has := range wanted {
+			if has && !current[class] {
+				added = append(added, class)
+			}
+		}
+		sort.Strings(added)
+		target = append(target, added...)
+
+		if len(added) > 0 || len(target) != len(stream.LayoutClassList) {
+			items = append(items, &StreamClass{ID: stream.ID, LayoutClassList: target})
+		}
+	}
+
+	m.pending = false
+	for id, last := range m.seen {
+		if present[id] {
+			continue
+		}
+
+		if now.Sub(last) < m.opts.ForgetAfter {
+			m.pending = true
+			continue
+		}
+
+		delete(m.desired, id)
+		delete(m.seen, id)
+
+		if m.focused == id {
+			m.focused = ""
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,220 @@
+package opentok
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Serve a stream list and record the class list updates.
+func newLayoutClassServer(t *testing.T, failures int) (*httptest.Server, func() []*StreamClassOptions) {
+	var (
+		mu      sync.Mutex
+		updates []*StreamClassOptions
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"count": 3, "items": [
+				{"id": "stream-1", "videoType": "camera", "name": "alice", "layoutClassList": ["full", "focus"]},
+				{"id": "stream-2", "videoType": "camera", "name": "bob", "layoutClassList": []},
+				{"id": "stream-3", "videoType": "screen", "name": "carol", "layoutClassList": ["full"]}
+			]}`))
+			return
+		}
+
+		assert.Equal(t, http.MethodPut, r.Method)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message": "Internal server error"}`))
+			return
+		}
+
+		opts := &StreamClassOptions{}
+		json.NewDecoder(r.Body).Decode(opts)
+		updates = append(updates, opts)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 0, "items": []}`))
+	}))
+
+	return ts, func() []*StreamClassOptions {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return updates
+	}
+}
+
+func TestLayoutClassManager_Flush(t *testing.T) {
+	ts, updates := newLayoutClassServer(t, 0)
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	manager := ot.NewLayoutClassManager("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", nil)
+	manager.Focus("stream-2")
+	manager.AddClass("stream-3", "full")
+	manager.RemoveClass("stream-3", "sidebar")
+	manager.AddClass("stream-gone", "full")
+
+	assert.Nil(t, manager.Flush(context.Background()))
+
+	if assert.Len(t, updates(), 1) {
+		assert.Equal(t, []*StreamClass{
+			{ID: "stream-1", LayoutClassList: []string{"full"}},
+			{ID: "stream-2", LayoutClassList: []string{"focus"}},
+		}, updates()[0].Items)
+	}
+
+	// The stream that is not listed is kept until it is forgotten.
+	manager.mu.Lock()
+	_, ok := manager.desired["stream-gone"]
+	manager.mu.Unlock()
+	assert.True(t, ok)
+
+	manager.Forget("stream-gone")
+
+	manager.mu.Lock()
+	_, ok = manager.desired["stream-gone"]
+	manager.mu.Unlock()
+	assert.False(t, ok)
+}
+
+func TestLayoutClassManager_ForgetAfter(t *testing.T) {
+	ts, _ := newLayoutClassServer(t, 0)
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	manager := ot.NewLayoutClassManager("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &LayoutClassManagerOptions{
+		ForgetAfter: 20 * time.Millisecond,
+	})
+	manager.Focus("stream-gone")
+	manager.AddClass("stream-gone", "full")
+
+	assert.Nil(t, manager.Flush(context.Background()))
+	assert.Equal(t, "stream-gone", manager.focused)
+
+	time.Sleep(30 * time.Millisecond)
+
+	assert.Nil(t, manager.Flush(context.Background()))
+	assert.Empty(t, manager.focused)
+	assert.Empty(t, manager.desired)
+}
+
+func TestLayoutClassManager_Run_LateStream(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		listed  bool
+		updates []*StreamClassOptions
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			if !listed {
+				// The stream is listed from the second request on.
+				listed = true
+				w.Write([]byte(`{"count": 0, "items": []}`))
+				return
+			}
+
+			w.Write([]byte(`{"count": 1, "items": [{"id": "stream-new", "videoType": "camera", "layoutClassList": []}]}`))
+			return
+		}
+
+		opts := &StreamClassOptions{}
+		json.NewDecoder(r.Body).Decode(opts)
+		updates = append(updates, opts)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 0, "items": []}`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	manager := ot.NewLayoutClassManager("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &LayoutClassManagerOptions{
+		BatchInterval: time.Millisecond,
+		RetryDelay:    time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- manager.Run(ctx)
+	}()
+
+	// The class is applied once the stream is listed, without another change.
+	manager.AddClass("stream-new", "full")
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(updates) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	assert.Equal(t, []*StreamClass{
+		{ID: "stream-new", LayoutClassList: []string{"full"}},
+	}, updates[0].Items)
+}
+
+func TestLayoutClassManager_NoChange(t *testing.T) {
+	ts, updates := newLayoutClassServer(t, 0)
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	manager := ot.NewLayoutClassManager("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", nil)
+	manager.AddClass("stream-1", "full")
+	manager.RemoveClass("stream-2", "full")
+
+	assert.Nil(t, manager.Flush(context.Background()))
+	assert.Empty(t, updates())
+}
+
+func TestLayoutClassManager_Run(t *testing.T) {
+	ts, updates := newLayoutClassServer(t, 1)
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	manager := ot.NewLayoutClassManager("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &LayoutClassManagerOptions{
+		BatchInterval: 20 * time.Millisecond,
+		RetryDelay:    time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- manager.Run(ctx)
+	}()
+
+	// The changes are applied together, after one failed attempt.
+	manager.Focus("stream-3")
+	manager.AddClass("stream-2", "sidebar")
+
+	assert.Eventually(t, func() bool { return len(updates()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	assert.Equal(t, []*StreamClass{
+		{ID: "stream-1", LayoutClassList: []string{"full"}},
+		{ID: "stream-2", LayoutClassList: []string{"sidebar"}},
+		{ID: "stream-3", LayoutClassList: []string{"full", "focus"}},
+	}, updates()[0].Items)
+}
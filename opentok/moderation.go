@@ -4,8 +4,50 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
 )
 
+// StreamFilter selects streams of a session. A stream matches if it satisfies
+// all the criteria set.
+type StreamFilter struct {
+	// The video type of the stream, either "camera" or "screen".
+	VideoType string
+
+	// A pattern the stream name must match.
+	NamePattern *regexp.Regexp
+
+	// A layout class the stream must have.
+	LayoutClass string
+}
+
+// Match reports whether the stream satisfies the filter. A nil filter matches
+// all streams.
+func (f *StreamFilter) Match(stream *Stream) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.VideoType != "" && stream.VideoType != f.VideoType {
+		return false
+	}
+
+	if f.NamePattern != nil && !f.NamePattern.MatchString(stream.Name) {
+		return false
+	}
+
+	if f.LayoutClass != "" {
+		for _, class := range stream.LayoutClassList {
+			if class == f.LayoutClass {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
 // ForceDisconnect disconnects a client from an OpenTok session via server-side.
 func (ot *OpenTok) ForceDisconnect(sessionID, connectionID string) error {
 	return ot.ForceDisconnectContext(context.Background(), sessionID, connectionID)
@@ -47,3 +89,93 @@ func (ot *OpenTok) ForceDisconnectContext(ctx context.Context, sessionID, connec
 
 	return nil
 }
+
+// DisconnectAll disconnects all clients from an OpenTok session, except for
+// the given connection IDs. It does not stop at the first failure: the result
+// maps each connection ID to its error (nil on success), and the returned error
+// is a *FanOutError if any disconnection failed.
+func (ot *OpenTok) DisconnectAll(sessionID string, except ...string) (map[string]error, error) {
+	return ot.DisconnectAllContext(context.Background(), sessionID, except...)
+}
+
+// DisconnectAllContext uses ctx for HTTP requests.
+func (ot *OpenTok) DisconnectAllContext(ctx context.Context, sessionID string, except ...string) (map[string]error, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Connections cannot be disconnected without a session ID")
+	}
+
+	connections, err := ot.listAllConnections(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := map[string]bool{}
+	for _, id := range except {
+		excluded[id] = true
+	}
+
+	targets := []string{}
+	for _, connection := range connections {
+		if !excluded[connection.ID] {
+			targets = append(targets, connection.ID)
+		}
+	}
+
+	return fanOut(ctx, targets, nil, func(ctx context.Context, connectionID string) error {
+		return ot.ForceDisconnectContext(ctx, sessionID, connectionID)
+	})
+}
+
+// MuteStreams forces the publishers of the streams of a session selected by
+// the filter to mute their audio. It does not stop at the first failure: the
+// result maps each stream ID to its error (nil on success), and the returned
+// error is a *FanOutError if any mute failed.
+func (ot *OpenTok) MuteStreams(sessionID string, filter *StreamFilter) (map[string]error, error) {
+	return ot.MuteStreamsContext(context.Background(), sessionID, filter)
+}
+
+// MuteStreamsContext uses ctx for HTTP requests.
+func (ot *OpenTok) MuteStreamsContext(ctx context.Context, sessionID string, filter *StreamFilter) (map[string]error, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Streams cannot be muted without a session ID")
+	}
+
+	streams, err := ot.ListStreamsContext(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []string{}
+	for _, stream := range streams.Items {
+		if filter.Match(stream) {
+			targets = append(targets, stream.ID)
+		}
+	}
+
+	return fanOut(ctx, targets, nil, func(ctx context.Context, streamID string) error {
+		_, err := ot.MuteStreamContext(ctx, sessionID, streamID)
+		return err
+	})
+}
+
+// DisconnectAll disconnects all clients from the session, except for the
+// given connection IDs.
+func (s *Session) DisconnectAll(except ...string) (map[string]error, error) {
+	return s.DisconnectAllContext(context.Background(), except...)
+}
+
+// DisconnectAllContext uses ctx for HTTP requests.
+func (s *Session) DisconnectAllContext(ctx context.Context, except ...string) (map[string]error, error) {
+	return s.OpenTok.DisconnectAllContext(ctx, s.SessionID, except...)
+}
+
+// MuteStreams forces the publishers of the streams selected by the filter to
+// mute their audio.
+func (s *Session) MuteStreams(filter *StreamFilter) (map[string]error, error) {
+	return s.MuteStreamsContext(context.Background(), filter)
+}
+
+// MuteStreamsContext uses ctx for HTTP requests.
+func (s *Session) MuteStreamsContext(ctx context.Context, filter *StreamFilter) (map[string]error, error) {
+	return s.OpenTok.MuteStreamsContext(ctx, s.SessionID, filter)
+}
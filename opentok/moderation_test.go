@@ -3,6 +3,9 @@ package opentok
 import (
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,3 +24,103 @@ func TestOpenTok_ForceDisconnect(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestOpenTok_DisconnectAll(t *testing.T) {
+	var (
+		mu           sync.Mutex
+		disconnected []string
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			assert.True(t, strings.HasSuffix(r.URL.Path, "/connection"))
+			assert.Equal(t, "1000", r.URL.Query().Get("count"))
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{
+				"count": 3,
+				"items": [
+					{"connectionId": "moderator", "connectionState": "Connected", "createdAt": 1384221730000},
+					{"connectionId": "intruder-1", "connectionState": "Connected", "createdAt": 1384221731000},
+					{"connectionId": "intruder-2", "connectionState": "Connecting", "createdAt": 1384221732000}
+				]
+			}`))
+			return
+		}
+
+		assert.Equal(t, http.MethodDelete, r.Method)
+
+		mu.Lock()
+		disconnected = append(disconnected, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	results, err := ot.DisconnectAll("40000001", "moderator")
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]error{"intruder-1": nil, "intruder-2": nil}, results)
+	assert.ElementsMatch(t, []string{"intruder-1", "intruder-2"}, disconnected)
+}
+
+func TestOpenTok_MuteStreams(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		muted []string
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{
+				"count": 3,
+				"items": [
+					{"id": "stream-1", "videoType": "camera", "name": "guest-alice", "layoutClassList": []},
+					{"id": "stream-2", "videoType": "camera", "name": "host-bob", "layoutClassList": ["focus"]},
+					{"id": "stream-3", "videoType": "screen", "name": "guest-carol", "layoutClassList": []}
+				]
+			}`))
+			return
+		}
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/mute"))
+
+		mu.Lock()
+		muted = append(muted, strings.Split(r.URL.Path, "/")[7])
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	results, err := ot.MuteStreams("40000001", &StreamFilter{
+		VideoType:   "camera",
+		NamePattern: regexp.MustCompile(`^guest-`),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]error{"stream-1": nil}, results)
+	assert.Equal(t, []string{"stream-1"}, muted)
+}
+
+func TestStreamFilter_Match(t *testing.T) {
+	stream := &Stream{
+		ID:              "stream-1",
+		VideoType:       "camera",
+		Name:            "guest-alice",
+		LayoutClassList: []string{"focus"},
+	}
+
+	var filter *StreamFilter
+	assert.True(t, filter.Match(stream))
+	assert.True(t, (&StreamFilter{LayoutClass: "focus"}).Match(stream))
+	assert.False(t, (&StreamFilter{LayoutClass: "full"}).Match(stream))
+	assert.False(t, (&StreamFilter{VideoType: "screen"}).Match(stream))
+	assert.False(t, (&StreamFilter{NamePattern: regexp.MustCompile(`^host-`)}).Match(stream))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httputil"
+	"strings"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v4"
@@ -55,6 +56,7 @@ type OpenTok struct {
 	debug     bool
 
 	httpClient HTTPClient
+	limiter    *rateLimiter
 }
 
 // New returns an initialized OpenTok instance with the API key and API secret.
@@ -133,6 +135,13 @@ func (ot *OpenTok) jwtToken(ist issueType) (string, error) {
 func (ot *OpenTok) sendRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
 	req.Header.Add("User-Agent", userAgent)
 
+	// Only the calls to the OpenTok API are rate limited.
+	if ot.limiter != nil && strings.HasPrefix(req.URL.String(), ot.apiHost) {
+		if err := ot.limiter.wait(ctx, rateClassOf(req)); err != nil {
+			return nil, err
+		}
+	}
+
 	// Dump request
 	if ot.debug {
 		fmt.Println("========== Request Begin ==========")
@@ -21,7 +21,7 @@ const (
 var ot = New(apiKey, apiSecret)
 
 func TestNew(t *testing.T) {
-	expect := &OpenTok{apiKey, apiSecret, defaultAPIHost, false, http.DefaultClient}
+	expect := &OpenTok{apiKey, apiSecret, defaultAPIHost, false, http.DefaultClient, nil}
 
 	actual := New(apiKey, apiSecret)
 
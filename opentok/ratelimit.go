@@ -0,0 +1,327 @@
+package opentok
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateClass is the alias of string type.
+type RateClass string
+
+const (
+	// RateClassGlobal applies to every REST API call.
+	RateClassGlobal RateClass = "global"
+
+	// RateClassSignal applies to signals sent to sessions and connections.
+	RateClassSignal RateClass = "signal"
+
+	// RateClassModeration applies to forced disconnects and forced mutes.
+	RateClassModeration RateClass = "moderation"
+
+	// RateClassArchive applies to the archive API calls.
+	RateClassArchive RateClass = "archive"
+
+	// RateClassAccount applies to the account management API calls.
+	RateClassAccount RateClass = "account"
+)
+
+// RateLimit defines a token bucket.
+type RateLimit struct {
+	// The number of requests allowed per second.
+	Rate float64
+
+	// The number of requests allowed in a burst, 1 by default.
+	Burst int
+}
+
+// RateLimiterOptions defines the client-side rate limits of REST API calls.
+type RateLimiterOptions struct {
+	// The limit applied to every call.
+	Global *RateLimit
+
+	// The limits applied to the calls of each class, in addition to the global
+	// limit.
+	Classes map[RateClass]*RateLimit
+
+	// OnWait is called after a call had to wait for the rate limiter.
+	OnWait func(class RateClass, wait time.Duration)
+}
+
+// RateLimiterMetrics defines the wait statistics of a rate limit.
+type RateLimiterMetrics struct {
+	// The number of calls that went through the limit.
+	Requests int64
+
+	// The number of calls that had to wait.
+	Delayed int64
+
+	// The total time spent waiting.
+	TotalWait time.Duration
+
+	// The longest wait.
+	MaxWait time.Duration
+}
+
+// Limits the calls made by an OpenTok instance.
+type rateLimiter struct {
+	onWait  func(class RateClass, wait time.Duration)
+	buckets map[RateClass]*tokenBucket
+
+	mu      sync.Mutex
+	metrics map[RateClass]*RateLimiterMetrics
+}
+
+// A token bucket refilled at a constant rate.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// SetRateLimiter limits the rate of the REST API calls made by the OpenTok
+// instance. Calls wait for the limiter until their context is done.
+// Pass nil to remove the limits.
+func (ot *OpenTok) SetRateLimiter(opts *RateLimiterOptions) {
+	if opts == nil {
+		ot.limiter = nil
+		return
+	}
+
+	limiter := &rateLimiter{
+		onWait:  opts.OnWait,
+		buckets: map[RateClass]*tokenBucket{},
+		metrics: map[RateClass]*RateLimiterMetrics{},
+	}
+
+	if opts.Global != nil {
+		limiter.buckets[RateClassGlobal] = newTokenBucket(opts.Global)
+	}
+
+	for class, limit := range opts.Classes {
+		if limit != nil {
+			limiter.buckets[class] = newTokenBucket(limit)
+		}
+	}
+
+	ot.limiter = limiter
+}
+
+// RateLimiterMetrics returns the wait statistics of each configured rate
+// limit. It is empty if no rate limiter is set.
+func (ot *OpenTok) RateLimiterMetrics() map[RateClass]RateLimiterMetrics {
+	metrics := map[RateClass]RateLimiterMetrics{}
+
+	if ot.limiter == nil {
+		return metrics
+	}
+
+	ot.limiter.mu.Lock()
+	defer ot.limiter.mu.Unlock()
+
+	for class, m := range ot.limiter.metrics {
+		metrics[class] = *m
+	}
+
+	return metrics
+}
+
+// Wait for the global limit and the limit of the class of the request.
+func (l *rateLimiter) wait(ctx context.Context, class RateClass) error {
+	classes := []RateClass{RateClassGlobal}
+	if class != "" {
+		classes = append(classes, class)
+	}
+
+	taken := []*tokenBucket{}
+	for _, c := range classes {
+		bucket, ok := l.buckets[c]
+		if !ok {
+			continue
+		}
+
+		wait, err := bucket.wait(ctx)
+
+		l.record(c, wait)
+
+		if err != nil {
+			// The request is not sent, so give back the tokens already taken.
+			for _, b := range taken {
+				b.refund()
+			}
+
+			return err
+		}
+
+		taken = append(taken, bucket)
+	}
+
+	return nil
+}
+
+// Update the metrics of a class.
+func (l *rateLimiter) record(class RateClass, wait time.Duration) {
+	l.mu.Lock()
+	m, ok := l.metrics[class]
+	if !ok {
+		m = &RateLimiterMetrics{}
+		l.metrics[class] = m
+	}
+
+	m.Requests++
+	if wait > 0 {
+		m.Delayed++
+		m.TotalWait += wait
+		if wait > m.MaxWait {
+			m.MaxWait = wait
+		}
+	}
+	l.mu.Unlock()
+
+	if wait > 0 && l.onWait != nil {
+		l.onWait(class, wait)
+	}
+}
+
+func newTokenBucket(limit *RateLimit) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Take a token if one is available.
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate <= 0 {
+		return true
+	}
+
+	b.refill(time.Now())
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// Report whether the bucket is full, meaning it has not been used for a
+// while.
+func (b *tokenBucket) full() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(time.Now())
+
+	return b.tokens >= b.burst
+}
+
+// Add the tokens accumulated since the last refill. b.mu must be held.
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+// Take a token, waiting for it if the bucket is empty. It returns how long it
+// waited.
+func (b *tokenBucket) wait(ctx context.Context) (time.Duration, error) {
+	if b.rate <= 0 {
+		return 0, nil
+	}
+
+	b.mu.Lock()
+	b.refill(time.Now())
+	b.tokens--
+
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if delay == 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	start := time.Now()
+
+	select {
+	case <-timer.C:
+		return delay, nil
+	case <-ctx.Done():
+		// Give the token back for the following calls.
+		b.refund()
+
+		return time.Since(start), ctx.Err()
+	}
+}
+
+// Give back a token taken by wait.
+func (b *tokenBucket) refund() {
+	if b.rate <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(time.Now())
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// Return the class of a REST API call from its method and path.
+func rateClassOf(req *http.Request) RateClass {
+	path := strings.Trim(req.URL.Path, "/")
+	if !strings.HasPrefix("/"+path, projectURL) {
+		return ""
+	}
+
+	segments := strings.Split(strings.TrimPrefix(strings.TrimPrefix("/"+path, projectURL), "/"), "/")
+
+	// Account management: /v2/project[/{apiKey}[/refreshSecret]]
+	if len(segments) <= 1 || (len(segments) == 2 && segments[1] == "refreshSecret") {
+		return RateClassAccount
+	}
+
+	rest := segments[1:]
+	last := rest[len(rest)-1]
+
+	switch {
+	case last == "signal":
+		return RateClassSignal
+	case rest[0] == "archive":
+		return RateClassArchive
+	case rest[0] == "session" && last == "mute":
+		return RateClassModeration
+	case rest[0] == "session" && len(rest) == 4 && rest[2] == "connection" && req.Method == http.MethodDelete:
+		return RateClassModeration
+	}
+
+	return ""
+}
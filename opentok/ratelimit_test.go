@@ -0,0 +1,122 @@
+package opentok
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateClassOf(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		class  RateClass
+	}{
+		{http.MethodGet, "/v2/project", RateClassAccount},
+		{http.MethodPut, "/v2/project/123456", RateClassAccount},
+		{http.MethodPost, "/v2/project/123456/refreshSecret", RateClassAccount},
+		{http.MethodPost, "/v2/project/123456/session/abc/signal", RateClassSignal},
+		{http.MethodPost, "/v2/project/123456/session/abc/connection/def/signal", RateClassSignal},
+		{http.MethodDelete, "/v2/project/123456/session/abc/connection/def", RateClassModeration},
+		{http.MethodPost, "/v2/project/123456/session/abc/mute", RateClassModeration},
+		{http.MethodPost, "/v2/project/123456/session/abc/stream/def/mute", RateClassModeration},
+		{http.MethodPost, "/v2/project/123456/archive", RateClassArchive},
+		{http.MethodPost, "/v2/project/123456/archive/abc/stop", RateClassArchive},
+		{http.MethodGet, "/v2/project/123456/session/abc/stream", ""},
+		{http.MethodPost, "/session/create", ""},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "https://api.opentok.com"+test.path, nil)
+		assert.Equal(t, test.class, rateClassOf(req), test.path)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	bucket := newTokenBucket(&RateLimit{Rate: 20, Burst: 2})
+
+	for i := 0; i < 2; i++ {
+		wait, err := bucket.wait(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, time.Duration(0), wait)
+	}
+
+	wait, err := bucket.wait(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, wait > 0 && wait <= 50*time.Millisecond)
+}
+
+func TestTokenBucket_Canceled(t *testing.T) {
+	bucket := newTokenBucket(&RateLimit{Rate: 0.1})
+	bucket.wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := bucket.wait(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestRateLimiter_RefundGlobal(t *testing.T) {
+	client := New(apiKey, apiSecret)
+	client.SetRateLimiter(&RateLimiterOptions{
+		Global: &RateLimit{Rate: 0.1},
+		Classes: map[RateClass]*RateLimit{
+			RateClassSignal: {Rate: 0.1},
+		},
+	})
+
+	// Empty the signal bucket without using the global one.
+	client.limiter.buckets[RateClassSignal].wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := client.limiter.wait(ctx, RateClassSignal)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// The global token taken by the cancelled request is given back.
+	assert.True(t, client.limiter.buckets[RateClassGlobal].full())
+}
+
+func TestOpenTok_SetRateLimiter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client := New(apiKey, apiSecret)
+	client.SetAPIHost(ts.URL)
+
+	waits := 0
+	client.SetRateLimiter(&RateLimiterOptions{
+		Classes: map[RateClass]*RateLimit{
+			RateClassSignal: {Rate: 50},
+		},
+		OnWait: func(class RateClass, wait time.Duration) {
+			assert.Equal(t, RateClassSignal, class)
+			waits++
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		err := client.SendSessionSignal("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &SignalData{
+			Type: "foo",
+			Data: "bar",
+		})
+		assert.Nil(t, err)
+	}
+
+	metrics := client.RateLimiterMetrics()
+	assert.Equal(t, int64(3), metrics[RateClassSignal].Requests)
+	assert.Equal(t, int64(2), metrics[RateClassSignal].Delayed)
+	assert.True(t, metrics[RateClassSignal].TotalWait > 0)
+	assert.Equal(t, 2, waits)
+
+	client.SetRateLimiter(nil)
+	assert.Empty(t, client.RateLimiterMetrics())
+}
@@ -0,0 +1,318 @@
+package opentok
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RenderProperties defines the properties of the stream published by an
+// Experience Composer.
+type RenderProperties struct {
+	// The name of the stream composed from the web page.
+	Name string `json:"name,omitempty"`
+}
+
+// RenderOptions defines the options for starting an Experience Composer.
+type RenderOptions struct {
+	// The OpenTok session ID to publish the web page into.
+	SessionID string `json:"sessionId"`
+
+	// The OpenTok token used by the Experience Composer to connect to the
+	// session.
+	Token string `json:"token"`
+
+	// The URL of the web page to render.
+	URL string `json:"url"`
+
+	// The maximum duration for the Experience Composer, in seconds.
+	MaxDuration int `json:"maxDuration,omitempty"`
+
+	// The resolution of the rendered web page.
+	Resolution Resolution `json:"resolution,omitempty"`
+
+	// The properties of the stream published into the session.
+	Properties *RenderProperties `json:"properties,omitempty"`
+
+	// The data for token generation
+	TokenData string `json:"-"`
+}
+
+// Render defines the response returned from API.
+type Render struct {
+	// The unique ID for the Experience Composer.
+	ID string `json:"id"`
+
+	// The OpenTok session ID.
+	SessionID string `json:"sessionId"`
+
+	// The API key associated with the Experience Composer.
+	ProjectID string `json:"projectId"`
+
+	// The time at which the Experience Composer was created, in milliseconds
+	// since the UNIX epoch.
+	CreatedAt int `json:"createdAt"`
+
+	// The time at which the Experience Composer was updated, in milliseconds
+	// since the UNIX epoch.
+	UpdatedAt int `json:"updatedAt"`
+
+	// The URL of the rendered web page.
+	URL string `json:"url"`
+
+	// The resolution of the Experience Composer.
+	Resolution Resolution `json:"resolution"`
+
+	// The status of the Experience Composer, either "starting", "started",
+	// "stopped" or "failed".
+	Status string `json:"status"`
+
+	// The ID of the stream published into the session, once started.
+	StreamID string `json:"streamId,omitempty"`
+
+	// The reason the Experience Composer stopped or failed.
+	Reason string `json:"reason,omitempty"`
+
+	// The instance of OpenTok.
+	OpenTok *OpenTok `json:"-"`
+}
+
+// RenderListOptions defines the query parameters to filter the list of
+// Experience Composers.
+type RenderListOptions struct {
+	// The start offset in the list of existing Experience Composers.
+	Offset int
+
+	// The number of Experience Composers to retrieve starting at offset.
+	Count int
+}
+
+// RenderList defines the response returned from API.
+type RenderList struct {
+	// The total number of Experience Composers in the results.
+	Count int `json:"count"`
+
+	// An array of objects defining each Experience Composer retrieved.
+	Items []*Render `json:"items"`
+}
+
+// StartRender starts an Experience Composer, which renders a web page and
+// publishes it into an OpenTok session as a stream.
+func (ot *OpenTok) StartRender(sessionID string, opts *RenderOptions) (*Render, error) {
+	return ot.StartRenderContext(context.Background(), sessionID, opts)
+}
+
+// StartRenderContext uses ctx for HTTP requests.
+func (ot *OpenTok) StartRenderContext(ctx context.Context, sessionID string, opts *RenderOptions) (*Render, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Experience Composer cannot be started without a session ID")
+	}
+
+	if opts == nil || opts.URL == "" {
+		return nil, fmt.Errorf("Experience Composer cannot be started without a URL")
+	}
+
+	if opts.MaxDuration != 0 && (opts.MaxDuration < 60 || opts.MaxDuration > 36000) {
+		return nil, fmt.Errorf("Invalid max duration for starting an Experience Composer, must be between 60 and 36000 seconds")
+	}
+
+	if opts.Resolution != "" && opts.Resolution != SDLandscape && opts.Resolution != HDLandscape &&
+		opts.Resolution != SDPortrait && opts.Resolution != HDPortrait &&
+		opts.Resolution != FHDLandscape && opts.Resolution != FHDPortrait {
+		return nil, fmt.Errorf("Invalid resolution for starting an Experience Composer")
+	}
+
+	token, err := ot.GenerateToken(sessionID, &TokenOptions{
+		Role: Publisher,
+		Data: opts.TokenData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opts.SessionID = sessionID
+	opts.Token = token
+
+	jsonStr, _ := json.Marshal(opts)
+
+	// Create jwt token
+	jwt, err := ot.genProjectJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := ot.apiHost + projectURL + "/" + ot.apiKey + "/render"
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewBuffer(jsonStr))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("X-OPENTOK-AUTH", jwt)
+
+	res, err := ot.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 202 {
+		return nil, parseErrorResponse(res)
+	}
+
+	render := &Render{}
+	if err := json.NewDecoder(res.Body).Decode(render); err != nil {
+		return nil, err
+	}
+
+	render.OpenTok = ot
+
+	return render, nil
+}
+
+// StopRender stops an Experience Composer.
+func (ot *OpenTok) StopRender(renderID string) error {
+	return ot.StopRenderContext(context.Background(), renderID)
+}
+
+// StopRenderContext uses ctx for HTTP requests.
+func (ot *OpenTok) StopRenderContext(ctx context.Context, renderID string) error {
+	if renderID == "" {
+		return fmt.Errorf("Experience Composer cannot be stopped without a render ID")
+	}
+
+	// Create jwt token
+	jwt, err := ot.genProjectJWT()
+	if err != nil {
+		return err
+	}
+
+	endpoint := ot.apiHost + projectURL + "/" + ot.apiKey + "/render/" + renderID
+	req, err := http.NewRequest(http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("X-OPENTOK-AUTH", jwt)
+
+	res, err := ot.sendRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 204 {
+		return parseErrorResponse(res)
+	}
+
+	return nil
+}
+
+// GetRender returns an Experience Composer details record.
+func (ot *OpenTok) GetRender(renderID string) (*Render, error) {
+	return ot.GetRenderContext(context.Background(), renderID)
+}
+
+// GetRenderContext uses ctx for HTTP requests.
+func (ot *OpenTok) GetRenderContext(ctx context.Context, renderID string) (*Render, error) {
+	if renderID == "" {
+		return nil, fmt.Errorf("Cannot get Experience Composer information without a render ID")
+	}
+
+	// Create jwt token
+	jwt, err := ot.genProjectJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := ot.apiHost + projectURL + "/" + ot.apiKey + "/render/" + renderID
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("X-OPENTOK-AUTH", jwt)
+
+	res, err := ot.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, parseErrorResponse(res)
+	}
+
+	render := &Render{}
+	if err := json.NewDecoder(res.Body).Decode(render); err != nil {
+		return nil, err
+	}
+
+	render.OpenTok = ot
+
+	return render, nil
+}
+
+// ListRenders returns the records of the Experience Composers of the project.
+func (ot *OpenTok) ListRenders(opts *RenderListOptions) (*RenderList, error) {
+	return ot.ListRendersContext(context.Background(), opts)
+}
+
+// ListRendersContext uses ctx for HTTP requests.
+func (ot *OpenTok) ListRendersContext(ctx context.Context, opts *RenderListOptions) (*RenderList, error) {
+	params := []string{"?"}
+
+	if opts != nil {
+		if opts.Offset != 0 {
+			params = append(params, "offset="+strconv.Itoa(opts.Offset))
+		}
+
+		if opts.Count != 0 {
+			params = append(params, "count="+strconv.Itoa(opts.Count))
+		}
+	}
+
+	// Create jwt token
+	jwt, err := ot.genProjectJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := ot.apiHost + projectURL + "/" + ot.apiKey + "/render" + strings.Join(params, "&")
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("X-OPENTOK-AUTH", jwt)
+
+	res, err := ot.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, parseErrorResponse(res)
+	}
+
+	renderList := &RenderList{}
+	if err := json.NewDecoder(res.Body).Decode(renderList); err != nil {
+		return nil, err
+	}
+
+	for _, render := range renderList.Items {
+		render.OpenTok = ot
+	}
+
+	return renderList, nil
+}
+
+// Stop stops the Experience Composer.
+func (render *Render) Stop() error {
+	return render.OpenTok.StopRender(render.ID)
+}
@@ -0,0 +1,168 @@
+package opentok
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenTok_StartRender(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		body := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "https://example.com/overlay", body["url"])
+		assert.NotEmpty(t, body["token"])
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`
+			{
+				"id": "1248e7070b81464c9789f46ad10e7764",
+				"sessionId": "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34",
+				"projectId": "40000001",
+				"createdAt": 1437676551000,
+				"updatedAt": 1437676551000,
+				"url": "https://example.com/overlay",
+				"resolution": "1280x720",
+				"status": "starting"
+			}
+		`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	expect := &Render{
+		ID:         "1248e7070b81464c9789f46ad10e7764",
+		SessionID:  "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34",
+		ProjectID:  "40000001",
+		CreatedAt:  1437676551000,
+		UpdatedAt:  1437676551000,
+		URL:        "https://example.com/overlay",
+		Resolution: HDLandscape,
+		Status:     "starting",
+		OpenTok:    ot,
+	}
+
+	actual, err := ot.StartRender("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &RenderOptions{
+		URL:         "https://example.com/overlay",
+		MaxDuration: 1800,
+		Resolution:  HDLandscape,
+		Properties: &RenderProperties{
+			Name: "Overlay",
+		},
+	})
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, expect, actual)
+	}
+}
+
+func TestOpenTok_StartRender_InvalidMaxDuration(t *testing.T) {
+	_, err := ot.StartRender("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &RenderOptions{
+		URL:         "https://example.com/overlay",
+		MaxDuration: 30,
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestOpenTok_StopRender(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	err := ot.StopRender("1248e7070b81464c9789f46ad10e7764")
+
+	assert.Nil(t, err)
+}
+
+func TestOpenTok_GetRender(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`
+			{
+				"id": "1248e7070b81464c9789f46ad10e7764",
+				"sessionId": "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34",
+				"projectId": "40000001",
+				"createdAt": 1437676551000,
+				"updatedAt": 1437676552000,
+				"url": "https://example.com/overlay",
+				"resolution": "1280x720",
+				"status": "started",
+				"streamId": "e32445b743678c98230f238"
+			}
+		`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	actual, err := ot.GetRender("1248e7070b81464c9789f46ad10e7764")
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, "started", actual.Status)
+		assert.Equal(t, "e32445b743678c98230f238", actual.StreamID)
+	}
+}
+
+func TestOpenTok_ListRenders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "50", r.URL.Query().Get("count"))
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`
+			{
+				"count": 1,
+				"items": [{
+					"id": "1248e7070b81464c9789f46ad10e7764",
+					"status": "started"
+				}]
+			}
+		`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	actual, err := ot.ListRenders(&RenderListOptions{
+		Count: 50,
+	})
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) && assert.Len(t, actual.Items, 1) {
+		assert.Equal(t, ot, actual.Items[0].OpenTok)
+	}
+}
+
+func TestRender_Stop(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	render := &Render{
+		ID:      "1248e7070b81464c9789f46ad10e7764",
+		OpenTok: ot,
+	}
+
+	err := render.Stop()
+
+	assert.Nil(t, err)
+}
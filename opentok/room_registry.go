@@ -0,0 +1,377 @@
+package opentok
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Room defines an application room and the session it is mapped to.
+type Room struct {
+	// The room name.
+	Name string `json:"name"`
+
+	// The session ID of the room.
+	SessionID string `json:"sessionId"`
+
+	// The time at which the room was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// The time at which the room expires. It is zero if the room does not
+	// expire.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Expired reports whether the room has expired at the given time.
+func (room *Room) Expired(now time.Time) bool {
+	return !room.ExpiresAt.IsZero() && !now.Before(room.ExpiresAt)
+}
+
+// RoomStore is the storage of a room registry. Implementations must be safe
+// for concurrent use.
+type RoomStore interface {
+	// Get returns the room with the name, or nil if there is none.
+	Get(ctx context.Context, name string) (*Room, error)
+
+	// Put saves the room, replacing the room with the same name.
+	Put(ctx context.Context, room *Room) error
+
+	// Delete removes the room with the name, if any.
+	Delete(ctx context.Context, name string) error
+}
+
+// RoomRegistryOptions defines the options of a room registry.
+type RoomRegistryOptions struct {
+	// The storage of the rooms, in memory by default.
+	Store RoomStore
+
+	// How long a room is mapped to its session. A room used after it has
+	// expired gets a new session. Zero means rooms do not expire.
+	TTL time.Duration
+
+	// How long creating the session of a room may take, 30 seconds by
+	// default. The creation is shared by all callers of GetOrCreate for the
+	// room, so it does not stop when one of them gives up.
+	CreateTimeout time.Duration
+}
+
+// RoomRegistry maps application room names to session IDs, so that all
+// participants of a room join the same session.
+type RoomRegistry struct {
+	ot   *OpenTok
+	opts RoomRegistryOptions
+
+	mu    sync.Mutex
+	calls map[string]*roomCall
+}
+
+// A GetOrCreate call in progress for a room.
+type roomCall struct {
+	done chan struct{}
+	room *Room
+	err  error
+}
+
+// NewRoomRegistry returns a room registry.
+func (ot *OpenTok) NewRoomRegistry(opts *RoomRegistryOptions) *RoomRegistry {
+	r := &RoomRegistry{
+		ot:    ot,
+		calls: map[string]*roomCall{},
+	}
+
+	if opts != nil {
+		r.opts = *opts
+	}
+
+	if r.opts.Store == nil {
+		r.opts.Store = NewMemoryRoomStore()
+	}
+
+	if r.opts.CreateTimeout <= 0 {
+		r.opts.CreateTimeout = 30 * time.Second
+	}
+
+	return r
+}
+
+// Get returns the room with the name, or nil if there is none or it has
+// expired.
+func (r *RoomRegistry) Get(ctx context.Context, roomName string) (*Room, error) {
+	room, err := r.opts.Store.Get(ctx, roomName)
+	if err != nil || room == nil {
+		return nil, err
+	}
+
+	if room.Expired(time.Now()) {
+		return nil, nil
+	}
+
+	return room, nil
+}
+
+// GetOrCreate returns the room with the name, creating a session for it with
+// the options if there is none or it has expired. Concurrent calls for the
+// same room share a single session creation, which keeps running when ctx is
+// done so that the other callers still get the room.
+func (r *RoomRegistry) GetOrCreate(ctx context.Context, roomName string, opts *SessionOptions) (*Room, error) {
+	if roomName == "" {
+		return nil, fmt.Errorf("Cannot get a room without a room name")
+	}
+
+	r.mu.Lock()
+	call, ok := r.calls[roomName]
+	if !ok {
+		call = &roomCall{done: make(chan struct{})}
+		r.calls[roomName] = call
+
+		go func() {
+			createCtx, cancel := context.WithTimeout(context.Background(), r.opts.CreateTimeout)
+			defer cancel()
+
+			call.room, call.err = r.getOrCreate(createCtx, roomName, opts)
+
+			r.mu.Lock()
+			delete(r.calls, roomName)
+			r.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.room, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Delete removes the room with the name, so that it gets a new session when
+// it is used again.
+func (r *RoomRegistry) Delete(ctx context.Context, roomName string) error {
+	return r.opts.Store.Delete(ctx, roomName)
+}
+
+// Look up the room, creating it if needed.
+func (r *RoomRegistry) getOrCreate(ctx context.Context, roomName string, opts *SessionOptions) (*Room, error) {
+	room, err := r.Get(ctx, roomName)
+	if err != nil || room != nil {
+		return room, err
+	}
+
+	if opts == nil {
+		opts = &SessionOptions{}
+	}
+
+	session, err := r.ot.CreateSessionContext(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	room = &Room{
+		Name:      roomName,
+		SessionID: session.SessionID,
+		CreatedAt: now,
+	}
+
+	if r.opts.TTL > 0 {
+		room.ExpiresAt = now.Add(r.opts.TTL)
+	}
+
+	if err := r.opts.Store.Put(ctx, room); err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+// MemoryRoomStore is a room store keeping the rooms in memory. Expired rooms
+// are dropped when they are looked up and whenever a room is saved.
+type MemoryRoomStore struct {
+	mu    sync.Mutex
+	rooms map[string]*Room
+}
+
+// NewMemoryRoomStore returns an empty in-memory room store.
+func NewMemoryRoomStore() *MemoryRoomStore {
+	return &MemoryRoomStore{
+		rooms: map[string]*Room{},
+	}
+}
+
+// Get returns the room with the name, or nil if there is none.
+func (s *MemoryRoomStore) Get(ctx context.Context, name string) (*Room, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, ok := s.rooms[name]
+	if !ok {
+		return nil, nil
+	}
+
+	if room.Expired(time.Now()) {
+		delete(s.rooms, name)
+		return nil, nil
+	}
+
+	copied := *room
+	return &copied, nil
+}
+
+// Put saves the room.
+func (s *MemoryRoomStore) Put(ctx context.Context, room *Room) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for name, r := range s.rooms {
+		if r.Expired(now) {
+			delete(s.rooms, name)
+		}
+	}
+
+	copied := *room
+	s.rooms[room.Name] = &copied
+
+	return nil
+}
+
+// Delete removes the room with the name.
+func (s *MemoryRoomStore) Delete(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.rooms, name)
+
+	return nil
+}
+
+// FileRoomStore is a room store keeping the rooms in a JSON file. The file
+// must not be shared between processes. Expired rooms are dropped whenever
+// the file is written.
+type FileRoomStore struct {
+	path string
+
+	mu    sync.Mutex
+	rooms map[string]*Room
+}
+
+// NewFileRoomStore returns a room store using the file at the path, loading
+// the rooms it contains if it exists.
+func NewFileRoomStore(path string) (*FileRoomStore, error) {
+	s := &FileRoomStore{
+		path:  path,
+		rooms: map[string]*Room{},
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &s.rooms); err != nil {
+		return nil, fmt.Errorf("Invalid room store file %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Get returns the room with the name, or nil if there is none.
+func (s *FileRoomStore) Get(ctx context.Context, name string) (*Room, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if room, ok := s.rooms[name]; ok {
+		copied := *room
+		return &copied, nil
+	}
+
+	return nil, nil
+}
+
+// Put saves the room and writes the file.
+func (s *FileRoomStore) Put(ctx context.Context, room *Room) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.rooms[room.Name]
+
+	copied := *room
+	s.rooms[room.Name] = &copied
+
+	if err := s.save(); err != nil {
+		if existed {
+			s.rooms[room.Name] = previous
+		} else {
+			delete(s.rooms, room.Name)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the room with the name and writes the file.
+func (s *FileRoomStore) Delete(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, ok := s.rooms[name]
+	if !ok {
+		return nil
+	}
+
+	delete(s.rooms, name)
+
+	if err := s.save(); err != nil {
+		s.rooms[name] = room
+		return err
+	}
+
+	return nil
+}
+
+// Write the rooms to a temporary file and move it over the file, so that the
+// file is never left half written. s.mu must be held.
+func (s *FileRoomStore) save() error {
+	now := time.Now()
+	for name, room := range s.rooms {
+		if room.Expired(now) {
+			delete(s.rooms, name)
+		}
+	}
+
+	data, err := json.MarshalIndent(s.rooms, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
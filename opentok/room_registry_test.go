@@ -0,0 +1,188 @@
+package opentok
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Serve session creations, returning a new session ID each time.
+func newSessionServer(t *testing.T) (*httptest.Server, func() int) {
+	var (
+		mu      sync.Mutex
+		created int
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		mu.Lock()
+		created++
+		n := created
+		mu.Unlock()
+
+		// Let concurrent requests pile up.
+		time.Sleep(10 * time.Millisecond)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`[{"session_id": "session-%d", "project_id": "40000001", "create_dt": "Wed Jan 01 00:00:00 PST 2020"}]`, n)))
+	}))
+
+	return ts, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return created
+	}
+}
+
+func TestRoomRegistry_GetOrCreate(t *testing.T) {
+	ts, created := newSessionServer(t)
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	registry := ot.NewRoomRegistry(nil)
+
+	var wg sync.WaitGroup
+	sessionIDs := make([]string, 10)
+	for i := range sessionIDs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			room, err := registry.GetOrCreate(context.Background(), "standup", &SessionOptions{MediaMode: Routed})
+			if assert.Nil(t, err) {
+				sessionIDs[i] = room.SessionID
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, created())
+	for _, sessionID := range sessionIDs {
+		assert.Equal(t, "session-1", sessionID)
+	}
+
+	room, err := registry.GetOrCreate(context.Background(), "retro", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "session-2", room.SessionID)
+	assert.True(t, room.ExpiresAt.IsZero())
+
+	assert.Nil(t, registry.Delete(context.Background(), "retro"))
+	room, err = registry.Get(context.Background(), "retro")
+	assert.Nil(t, err)
+	assert.Nil(t, room)
+}
+
+func TestRoomRegistry_GetOrCreate_CanceledCaller(t *testing.T) {
+	ts, created := newSessionServer(t)
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	registry := ot.NewRoomRegistry(nil)
+
+	// The first caller gives up while the session is being created.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := registry.GetOrCreate(ctx, "standup", nil)
+	assert.Equal(t, context.Canceled, err)
+
+	// The other callers still get the room.
+	room, err := registry.GetOrCreate(context.Background(), "standup", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "session-1", room.SessionID)
+	assert.Equal(t, 1, created())
+}
+
+func TestRoomRegistry_TTL(t *testing.T) {
+	ts, created := newSessionServer(t)
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	registry := ot.NewRoomRegistry(&RoomRegistryOptions{
+		TTL: 20 * time.Millisecond,
+	})
+
+	room, err := registry.GetOrCreate(context.Background(), "standup", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "session-1", room.SessionID)
+	assert.False(t, room.ExpiresAt.IsZero())
+
+	time.Sleep(30 * time.Millisecond)
+
+	room, err = registry.Get(context.Background(), "standup")
+	assert.Nil(t, err)
+	assert.Nil(t, room)
+
+	room, err = registry.GetOrCreate(context.Background(), "standup", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "session-2", room.SessionID)
+	assert.Equal(t, 2, created())
+}
+
+func TestMemoryRoomStore_Expired(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRoomStore()
+
+	expiresAt := time.Now().Add(-time.Minute)
+	assert.Nil(t, store.Put(ctx, &Room{Name: "standup", SessionID: "session-1", ExpiresAt: expiresAt}))
+	assert.Nil(t, store.Put(ctx, &Room{Name: "retro", SessionID: "session-2", ExpiresAt: expiresAt}))
+	assert.Len(t, store.rooms, 1)
+
+	room, err := store.Get(ctx, "retro")
+	assert.Nil(t, err)
+	assert.Nil(t, room)
+	assert.Empty(t, store.rooms)
+}
+
+func TestFileRoomStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "opentok")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rooms.json")
+	ctx := context.Background()
+
+	store, err := NewFileRoomStore(path)
+	assert.Nil(t, err)
+
+	room, err := store.Get(ctx, "standup")
+	assert.Nil(t, err)
+	assert.Nil(t, room)
+
+	createdAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, store.Put(ctx, &Room{Name: "standup", SessionID: "session-1", CreatedAt: createdAt}))
+	assert.Nil(t, store.Put(ctx, &Room{Name: "expired", SessionID: "session-2", CreatedAt: createdAt, ExpiresAt: createdAt}))
+	assert.Nil(t, store.Put(ctx, &Room{Name: "retro", SessionID: "session-3", CreatedAt: createdAt}))
+	assert.Nil(t, store.Delete(ctx, "retro"))
+
+	// Reopen the file.
+	store, err = NewFileRoomStore(path)
+	assert.Nil(t, err)
+
+	room, err = store.Get(ctx, "standup")
+	assert.Nil(t, err)
+	assert.Equal(t, &Room{Name: "standup", SessionID: "session-1", CreatedAt: createdAt}, room)
+
+	for _, name := range []string{"expired", "retro"} {
+		room, err = store.Get(ctx, name)
+		assert.Nil(t, err)
+		assert.Nil(t, room, name)
+	}
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte("not json"), 0644))
+	_, err = NewFileRoomStore(path)
+	assert.NotNil(t, err)
+}
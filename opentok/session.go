@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -57,6 +58,41 @@ type SessionOptions struct {
 	// streams directly to other clients; set to disabled for sessions that use
 	// the OpenTok Media Router.
 	MediaMode MediaMode
+
+	// Whether to enable end-to-end encryption for the session. It requires
+	// the routed media mode and cannot be used with automatic archiving.
+	E2EE bool
+
+	// The name of the archives of an automatically archived session.
+	ArchiveName string
+
+	// The resolution of the archives of an automatically archived session.
+	ArchiveResolution Resolution
+}
+
+// Validate checks that the options can be used together.
+func (opts *SessionOptions) Validate() error {
+	if opts.Location != "" && net.ParseIP(opts.Location) == nil {
+		return fmt.Errorf("Invalid location for session creation, must be an IPv4 or IPv6 address: %s", opts.Location)
+	}
+
+	if opts.E2EE && opts.MediaMode != Routed {
+		return fmt.Errorf("End-to-end encryption requires the routed media mode")
+	}
+
+	if opts.E2EE && opts.ArchiveMode == AutoArchived {
+		return fmt.Errorf("End-to-end encryption cannot be used with automatic archiving")
+	}
+
+	if opts.ArchiveMode == AutoArchived && opts.MediaMode != Routed {
+		return fmt.Errorf("Automatic archiving requires the routed media mode")
+	}
+
+	if (opts.ArchiveName != "" || opts.ArchiveResolution != "") && opts.ArchiveMode != AutoArchived {
+		return fmt.Errorf("Archive name and resolution can only be set for automatically archived sessions")
+	}
+
+	return nil
 }
 
 // Session defines the response returned from API.
@@ -67,12 +103,32 @@ type Session struct {
 	// The API key associated with the project.
 	ProjectID string `json:"project_id"`
 
-	// The time at which the session was created.
+	// The time at which the session was created, as returned by the API.
 	CreateDt string `json:"create_dt"`
 
+	// The time at which the session was created, in UTC.
+	CreatedAt time.Time `json:"created_at"`
+
+	// The media mode of the session.
+	MediaMode MediaMode `json:"media_mode,omitempty"`
+
+	// The archive mode of the session.
+	ArchiveMode ArchiveMode `json:"archive_mode,omitempty"`
+
+	// The IP address used to situate the session, if any.
+	Location string `json:"location,omitempty"`
+
+	// Whether the session uses end-to-end encryption.
+	E2EE bool `json:"e2ee,omitempty"`
+
 	// The URL of the OpenTok media router used by the session.
 	MediaServerURL string `json:"media_server_url"`
 
+	// The force mute state of the session, as set by the Mute, DisableForceMute
+	// and ExcludeStreams methods of the session. It is nil until one of them
+	// is called. It is not updated when streams join or leave the session.
+	MuteState *MuteState `json:"mute_state,omitempty"`
+
 	// The instance of OpenTok.
 	OpenTok *OpenTok `json:"-"`
 }
@@ -126,10 +182,56 @@ type SessionIDInfo struct {
 type MuteOptions struct {
 	// Active specifies whether streams published after this call, in addition to
 	// the current streams in the session, should be muted (true) or not (false).
-	Active bool
+	Active bool `json:"active"`
 
 	// ExcludedStreams specifies stream IDs for streams that should not be muted.
+	ExcludedStreams []string `json:"excludedStreamIds,omitempty"`
+}
+
+// MuteResult defines the result of a force mute request for a session.
+type MuteResult struct {
+	// The session ID.
+	SessionID string
+
+	// Whether the streams published after the request are muted.
+	Active bool
+
+	// The IDs of the streams that are not muted.
 	ExcludedStreams []string
+
+	// The project details returned by the API.
+	Project *Project
+}
+
+// MuteState defines the force mute state of a session.
+type MuteState struct {
+	// Whether the streams published to the session are muted.
+	Active bool `json:"active"`
+
+	// The IDs of the streams excluded from the force mute by the last call to
+	// Mute or DisableForceMute.
+	ExcludedStreams []string `json:"excluded_streams,omitempty"`
+
+	// The IDs of the streams recorded by ExcludeStreams since then. They are
+	// muted like the other streams until the next call to Mute.
+	PendingExclusions []string `json:"pending_exclusions,omitempty"`
+}
+
+// IsExcluded reports whether the stream is excluded from the force mute. The
+// pending exclusions are not reported until they are applied.
+func (m *MuteState) IsExcluded(streamID string) bool {
+	return containsStreamID(m.ExcludedStreams, streamID)
+}
+
+// Report whether the stream ID is in the list.
+func containsStreamID(streamIDs []string, streamID string) bool {
+	for _, id := range streamIDs {
+		if id == streamID {
+			return true
+		}
+	}
+
+	return false
 }
 
 // CreateSession generates a new session.
@@ -139,6 +241,14 @@ func (ot *OpenTok) CreateSession(opts *SessionOptions) (*Session, error) {
 
 // CreateSessionContext uses ctx for HTTP requests.
 func (ot *OpenTok) CreateSessionContext(ctx context.Context, opts *SessionOptions) (*Session, error) {
+	if opts == nil {
+		opts = &SessionOptions{}
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
 	params := url.Values{}
 
 	if opts.ArchiveMode != "" {
@@ -153,6 +263,18 @@ func (ot *OpenTok) CreateSessionContext(ctx context.Context, opts *SessionOption
 		params.Add("p2p.preference", string(opts.MediaMode))
 	}
 
+	if opts.E2EE {
+		params.Add("e2ee", "true")
+	}
+
+	if opts.ArchiveName != "" {
+		params.Add("archiveName", opts.ArchiveName)
+	}
+
+	if opts.ArchiveResolution != "" {
+		params.Add("archiveResolution", string(opts.ArchiveResolution))
+	}
+
 	// Create jwt token
 	jwt, err := ot.genProjectJWT()
 	if err != nil {
@@ -190,9 +312,56 @@ func (ot *OpenTok) CreateSessionContext(ctx context.Context, opts *SessionOption
 	session := sessions[0]
 	session.OpenTok = ot
 
+	// Record the effective options, which default to a relayed session with
+	// manual archiving.
+	session.MediaMode = opts.MediaMode
+	if session.MediaMode == "" {
+		session.MediaMode = Relayed
+	}
+
+	session.ArchiveMode = opts.ArchiveMode
+	if session.ArchiveMode == "" {
+		session.ArchiveMode = ManualArchived
+	}
+
+	session.Location = opts.Location
+	session.E2EE = opts.E2EE
+
+	if info, err := decodeSessionID(session.SessionID); err == nil {
+		session.CreatedAt = info.CreateTime.UTC()
+		if session.Location == "" {
+			session.Location = info.Location
+		}
+	} else if createdAt, err := parseCreateDt(session.CreateDt); err == nil {
+		session.CreatedAt = createdAt
+	}
+
 	return &session, nil
 }
 
+// SessionFromID returns a session for a session ID created earlier, for
+// example one stored in a database. The creation time and location are
+// decoded from the session ID; the media and archive modes are not encoded in
+// it and are left empty.
+func (ot *OpenTok) SessionFromID(sessionID string) (*Session, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Session cannot be restored without a session ID")
+	}
+
+	info, err := decodeSessionID(sessionID)
+	if err != nil || info.APIKey != ot.apiKey {
+		return nil, fmt.Errorf("Session cannot be restored unless the session belongs to the API Key")
+	}
+
+	return &Session{
+		SessionID: sessionID,
+		ProjectID: info.APIKey,
+		CreatedAt: info.CreateTime.UTC(),
+		Location:  info.Location,
+		OpenTok:   ot,
+	}, nil
+}
+
 // GenerateToken generates a token for each user connecting to an OpenTok
 // session.
 func (ot *OpenTok) GenerateToken(sessionID string, opts *TokenOptions) (string, error) {
@@ -255,12 +424,20 @@ func (ot *OpenTok) GenerateToken(sessionID string, opts *TokenOptions) (string,
 
 // MuteSession force all streams (except for an optional list of streams)
 // in a session to mute published audio.
-func (ot *OpenTok) MuteSession(sessionID string, opts *MuteOptions) (*Project, error) {
+func (ot *OpenTok) MuteSession(sessionID string, opts *MuteOptions) (*MuteResult, error) {
 	return ot.MuteSessionContext(context.Background(), sessionID, opts)
 }
 
 // MuteSessionContext uses ctx for HTTP requests.
-func (ot *OpenTok) MuteSessionContext(ctx context.Context, sessionID string, opts *MuteOptions) (*Project, error) {
+func (ot *OpenTok) MuteSessionContext(ctx context.Context, sessionID string, opts *MuteOptions) (*MuteResult, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Session cannot be muted without a session ID")
+	}
+
+	if opts == nil {
+		return nil, fmt.Errorf("Session cannot be muted without mute options")
+	}
+
 	jsonStr, _ := json.Marshal(opts)
 
 	// Create jwt token
@@ -293,7 +470,30 @@ func (ot *OpenTok) MuteSessionContext(ctx context.Context, sessionID string, opt
 		return nil, err
 	}
 
-	return project, nil
+	result := &MuteResult{
+		SessionID: sessionID,
+		Active:    opts.Active,
+		Project:   project,
+	}
+
+	// The excluded streams are ignored when the mute state is disabled.
+	if opts.Active {
+		result.ExcludedStreams = append([]string{}, opts.ExcludedStreams...)
+	}
+
+	return result, nil
+}
+
+// DisableForceMute disables the force mute state of a session, so that the
+// streams published afterwards are no longer muted. The streams that are
+// already muted remain muted.
+func (ot *OpenTok) DisableForceMute(sessionID string) (*MuteResult, error) {
+	return ot.DisableForceMuteContext(context.Background(), sessionID)
+}
+
+// DisableForceMuteContext uses ctx for HTTP requests.
+func (ot *OpenTok) DisableForceMuteContext(ctx context.Context, sessionID string) (*MuteResult, error) {
+	return ot.MuteSessionContext(ctx, sessionID, &MuteOptions{Active: false})
 }
 
 // MuteStream force a publisher of a specific stream to mute its audio.
@@ -343,13 +543,79 @@ func (s *Session) GenerateToken(opts *TokenOptions) (string, error) {
 
 // Mute force all force all streams (except for an optional list of streams)
 // in a session to mute published audio.
-func (s *Session) Mute(opts *MuteOptions) (*Project, error) {
+// The streams excluded by earlier calls stay excluded, and the new mute state
+// of the session is recorded. Every call with Active set mutes all current
+// streams that are not excluded, including participants who have unmuted
+// themselves since the last call.
+func (s *Session) Mute(opts *MuteOptions) (*MuteResult, error) {
 	return s.MuteContext(context.Background(), opts)
 }
 
 // MuteContext uses ctx for HTTP requests.
-func (s *Session) MuteContext(ctx context.Context, opts *MuteOptions) (*Project, error) {
-	return s.OpenTok.MuteSessionContext(ctx, s.SessionID, opts)
+func (s *Session) MuteContext(ctx context.Context, opts *MuteOptions) (*MuteResult, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("Session cannot be muted without mute options")
+	}
+
+	requested := opts.ExcludedStreams
+	excluded := []string{}
+	if s.MuteState != nil {
+		excluded = append(excluded, s.MuteState.ExcludedStreams...)
+		requested = append(append([]string{}, s.MuteState.PendingExclusions...), requested...)
+	}
+
+	state := &MuteState{ExcludedStreams: excluded}
+	for _, id := range requested {
+		if !state.IsExcluded(id) {
+			state.ExcludedStreams = append(state.ExcludedStreams, id)
+		}
+	}
+
+	result, err := s.OpenTok.MuteSessionContext(ctx, s.SessionID, &MuteOptions{
+		Active:          opts.Active,
+		ExcludedStreams: state.ExcludedStreams,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state.Active = result.Active
+	s.MuteState = state
+
+	return result, nil
+}
+
+// DisableForceMute disables the force mute state of the session. The excluded
+// streams are kept for the next call to Mute.
+func (s *Session) DisableForceMute() (*MuteResult, error) {
+	return s.DisableForceMuteContext(context.Background())
+}
+
+// DisableForceMuteContext uses ctx for HTTP requests.
+func (s *Session) DisableForceMuteContext(ctx context.Context) (*MuteResult, error) {
+	return s.MuteContext(ctx, &MuteOptions{Active: false})
+}
+
+// ExcludeStreams records streams to exclude from the force mute of the
+// session, for example when a presenter publishes a new stream. No request is
+// made: the streams are added to the pending exclusions, which are sent with
+// the next call to Mute. Until then, the server mutes them like the other
+// streams while the force mute is active.
+func (s *Session) ExcludeStreams(streamIDs ...string) {
+	state := &MuteState{}
+	if s.MuteState != nil {
+		state.Active = s.MuteState.Active
+		state.ExcludedStreams = append(state.ExcludedStreams, s.MuteState.ExcludedStreams...)
+		state.PendingExclusions = append(state.PendingExclusions, s.MuteState.PendingExclusions...)
+	}
+
+	for _, id := range streamIDs {
+		if !state.IsExcluded(id) && !containsStreamID(state.PendingExclusions, id) {
+			state.PendingExclusions = append(state.PendingExclusions, id)
+		}
+	}
+
+	s.MuteState = state
 }
 
 // MuteStream force a publisher of a specific stream to mute its audio.
@@ -362,8 +628,32 @@ func (s *Session) MuteStreamContext(ctx context.Context, streamID string) (*Proj
 	return s.OpenTok.MuteStreamContext(ctx, s.SessionID, streamID)
 }
 
+// Parse a creation time such as "Wed Jan 01 00:00:00 PST 2020". Session IDs
+// that cannot be decoded fall back to the create_dt field of the create
+// session response, which the REST API reports in US Pacific time.
+func parseCreateDt(createDt string) (time.Time, error) {
+	t, err := time.Parse(time.UnixDate, createDt)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// time.Parse only knows the offsets of the zones of the local time zone.
+	switch name, _ := t.Zone(); name {
+	case "PST":
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.FixedZone(name, -8*3600))
+	case "PDT":
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.FixedZone(name, -7*3600))
+	}
+
+	return t.UTC(), nil
+}
+
 // Decodes a sessionID into the metadata that it contains
 func decodeSessionID(sessionID string) (*SessionIDInfo, error) {
+	if len(sessionID) < 2 {
+		return nil, fmt.Errorf("Invalid session ID")
+	}
+
 	// remove sentinel
 	sessionID = sessionID[2:]
 
@@ -376,6 +666,9 @@ func decodeSessionID(sessionID string) (*SessionIDInfo, error) {
 
 	// separate fields
 	fields := strings.Split(string(decodedSessionID), "~")
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("Invalid session ID")
+	}
 
 	ts, err := strconv.ParseInt(fields[3], 10, 64)
 	if err != nil {
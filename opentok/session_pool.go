@@ -0,0 +1,269 @@
+package opentok
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SessionPoolOptions defines the options of a session pool.
+type SessionPoolOptions struct {
+	// The number of sessions kept ready for each profile, 5 by default.
+	Size int
+
+	// The delay before retrying after a failed refill, doubled after each
+	// failure, 1 second by default.
+	MinBackoff time.Duration
+
+	// The maximum delay between refill retries, 1 minute by default.
+	MaxBackoff time.Duration
+}
+
+// SessionPoolMetrics defines the statistics of a session pool.
+type SessionPoolMetrics struct {
+	// The number of acquisitions served from the pool.
+	Hits int64
+
+	// The number of acquisitions that had to create a session.
+	Misses int64
+
+	// The number of failed session creations while refilling the pool.
+	RefillErrors int64
+
+	// The number of sessions ready in the pool.
+	Available int
+}
+
+// HitRate returns the share of acquisitions served from the pool, between 0
+// and 1.
+func (m SessionPoolMetrics) HitRate() float64 {
+	if m.Hits+m.Misses == 0 {
+		return 0
+	}
+
+	return float64(m.Hits) / float64(m.Hits+m.Misses)
+}
+
+// SessionPool keeps sessions created in advance for each profile of session
+// options, so that starting a meeting does not wait for the API.
+type SessionPool struct {
+	ot   *OpenTok
+	opts SessionPoolOptions
+
+	mu       sync.Mutex
+	profiles map[SessionOptions]*poolProfile
+	metrics  SessionPoolMetrics
+
+	wake chan struct{}
+}
+
+// The sessions and refill state of a profile.
+type poolProfile struct {
+	sessions    []*Session
+	backoff     time.Duration
+	nextAttempt time.Time
+}
+
+// NewSessionPool returns a session pool. Call Run to fill it in the
+// background.
+func (ot *OpenTok) NewSessionPool(opts *SessionPoolOptions) *SessionPool {
+	p := &SessionPool{
+		ot:       ot,
+		profiles: map[SessionOptions]*poolProfile{},
+		wake:     make(chan struct{}, 1),
+	}
+
+	if opts != nil {
+		p.opts = *opts
+	}
+
+	if p.opts.Size <= 0 {
+		p.opts.Size = 5
+	}
+
+	if p.opts.MinBackoff <= 0 {
+		p.opts.MinBackoff = time.Second
+	}
+
+	if p.opts.MaxBackoff <= 0 {
+		p.opts.MaxBackoff = time.Minute
+	}
+
+	if p.opts.MaxBackoff < p.opts.MinBackoff {
+		p.opts.MaxBackoff = p.opts.MinBackoff
+	}
+
+	return p
+}
+
+// Warm makes the pool keep sessions ready for the profile. Profiles are also
+// added when they are first acquired.
+func (p *SessionPool) Warm(profile *SessionOptions) {
+	p.mu.Lock()
+	p.profile(profile)
+	p.mu.Unlock()
+
+	p.notify()
+}
+
+// Acquire returns a session of the profile from the pool, or creates one if
+// the pool has none ready.
+func (p *SessionPool) Acquire(ctx context.Context, profile *SessionOptions) (*Session, error) {
+	p.mu.Lock()
+	pp := p.profile(profile)
+	if n := len(pp.sessions); n > 0 {
+		session := pp.sessions[0]
+		pp.sessions = pp.sessions[1:]
+		p.metrics.Hits++
+		p.mu.Unlock()
+
+		p.notify()
+
+		return session, nil
+	}
+	p.metrics.Misses++
+	p.mu.Unlock()
+
+	p.notify()
+
+	opts := SessionOptions{}
+	if profile != nil {
+		opts = *profile
+	}
+
+	return p.ot.CreateSessionContext(ctx, &opts)
+}
+
+// Metrics returns the statistics of the pool.
+func (p *SessionPool) Metrics() SessionPoolMetrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	metrics := p.metrics
+	for _, pp := range p.profiles {
+		metrics.Available += len(pp.sessions)
+	}
+
+	return metrics
+}
+
+// Run fills the pool until ctx is done. Failed creations are retried with an
+// exponential backoff.
+func (p *SessionPool) Run(ctx context.Context) error {
+	for {
+		wait, err := p.refill(ctx)
+		if err != nil {
+			return err
+		}
+
+		var (
+			timer *time.Timer
+			retry <-chan time.Time
+		)
+
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			retry = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-p.wake:
+		case <-retry:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Return the state of a profile, adding it if needed. p.mu must be held.
+func (p *SessionPool) profile(profile *SessionOptions) *poolProfile {
+	key := SessionOptions{}
+	if profile != nil {
+		key = *profile
+	}
+
+	pp, ok := p.profiles[key]
+	if !ok {
+		pp = &poolProfile{}
+		p.profiles[key] = pp
+	}
+
+	return pp
+}
+
+// Wake up Run, unless it is already due to run.
+func (p *SessionPool) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Create sessions for the profiles that are short of sessions and not backing
+// off. It returns how long to wait before the next backoff ends, or zero if
+// no profile is backing off. It only returns an error if ctx is done.
+func (p *SessionPool) refill(ctx context.Context) (time.Duration, error) {
+	for {
+		p.mu.Lock()
+		now := time.Now()
+
+		var (
+			key   SessionOptions
+			found bool
+			wait  time.Duration
+		)
+
+		for k, pp := range p.profiles {
+			if len(pp.sessions) >= p.opts.Size {
+				continue
+			}
+
+			if now.Before(pp.nextAttempt) {
+				if d := pp.nextAttempt.Sub(now); wait == 0 || d < wait {
+					wait = d
+				}
+				continue
+			}
+
+			key, found = k, true
+			break
+		}
+		p.mu.Unlock()
+
+		if !found {
+			return wait, nil
+		}
+
+		opts := key
+		session, err := p.ot.CreateSessionContext(ctx, &opts)
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+
+		p.mu.Lock()
+		pp := p.profiles[key]
+		if err != nil {
+			p.metrics.RefillErrors++
+
+			if pp.backoff == 0 {
+				pp.backoff = p.opts.MinBackoff
+			} else if pp.backoff *= 2; pp.backoff > p.opts.MaxBackoff {
+				pp.backoff = p.opts.MaxBackoff
+			}
+			pp.nextAttempt = time.Now().Add(pp.backoff)
+		} else {
+			pp.backoff = 0
+			pp.nextAttempt = time.Time{}
+			pp.sessions = append(pp.sessions, session)
+		}
+		p.mu.Unlock()
+	}
+}
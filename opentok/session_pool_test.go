@@ -0,0 +1,112 @@
+package opentok
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionPool(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		created  int
+		failures = 2
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message": "Internal server error"}`))
+			return
+		}
+
+		created++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`[{"session_id": "%s-%d", "project_id": "40000001"}]`, r.PostForm.Get("p2p.preference"), created)))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	pool := ot.NewSessionPool(&SessionPoolOptions{
+		Size:       2,
+		MinBackoff: time.Millisecond,
+	})
+
+	routed := &SessionOptions{MediaMode: Routed}
+
+	// The pool is empty until it is filled.
+	session, err := pool.Acquire(context.Background(), routed)
+	assert.NotNil(t, err)
+	assert.Nil(t, session)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- pool.Run(ctx)
+	}()
+
+	assert.Eventually(t, func() bool { return pool.Metrics().Available == 2 }, 5*time.Second, time.Millisecond)
+
+	session, err = pool.Acquire(context.Background(), routed)
+	assert.Nil(t, err)
+	assert.Equal(t, "disabled-1", session.SessionID)
+
+	// The pool is refilled after each acquisition.
+	assert.Eventually(t, func() bool { return pool.Metrics().Available == 2 }, 5*time.Second, time.Millisecond)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	metrics := pool.Metrics()
+	assert.Equal(t, int64(1), metrics.Hits)
+	assert.Equal(t, int64(1), metrics.Misses)
+	assert.True(t, metrics.RefillErrors >= 1)
+	assert.Equal(t, 0.5, metrics.HitRate())
+}
+
+func TestSessionPool_Profiles(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"session_id": "` + r.PostForm.Get("p2p.preference") + r.PostForm.Get("archiveMode") + `", "project_id": "40000001"}]`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	pool := ot.NewSessionPool(&SessionPoolOptions{Size: 1})
+	pool.Warm(&SessionOptions{MediaMode: Relayed})
+	pool.Warm(&SessionOptions{MediaMode: Routed, ArchiveMode: AutoArchived})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- pool.Run(ctx)
+	}()
+
+	assert.Eventually(t, func() bool { return pool.Metrics().Available == 2 }, 5*time.Second, time.Millisecond)
+
+	session, err := pool.Acquire(context.Background(), &SessionOptions{MediaMode: Routed, ArchiveMode: AutoArchived})
+	assert.Nil(t, err)
+	assert.Equal(t, "disabledalways", session.SessionID)
+
+	session, err = pool.Acquire(context.Background(), &SessionOptions{MediaMode: Relayed})
+	assert.Nil(t, err)
+	assert.Equal(t, "enabled", session.SessionID)
+
+	// Stop refilling before the next test changes the API host.
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
@@ -1,8 +1,10 @@
 package opentok
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -41,6 +43,9 @@ func TestOpenTok_CreateSession(t *testing.T) {
 		SessionID:      "1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4",
 		ProjectID:      "40000001",
 		CreateDt:       "Wed Jan 01 00:00:00 PST 2020",
+		CreatedAt:      time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC),
+		MediaMode:      Routed,
+		ArchiveMode:    AutoArchived,
 		MediaServerURL: "",
 		OpenTok:        ot,
 	}
@@ -57,6 +62,120 @@ func TestOpenTok_CreateSession(t *testing.T) {
 	}
 }
 
+func TestOpenTok_CreateSession_NilOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Empty(t, r.PostForm)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"session_id": "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", "project_id": "40000001", "create_dt": "Wed Jan 01 00:00:00 PST 2020"}]`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	actual, err := ot.CreateSession(nil)
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, Relayed, actual.MediaMode)
+		assert.Equal(t, ManualArchived, actual.ArchiveMode)
+		assert.Equal(t, time.Unix(1577865600, 0).UTC(), actual.CreatedAt)
+	}
+}
+
+func TestOpenTok_SessionFromID(t *testing.T) {
+	session, err := ot.SessionFromID("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34")
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, session) {
+		assert.Equal(t, "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", session.SessionID)
+		assert.Equal(t, apiKey, session.ProjectID)
+		assert.Equal(t, time.Unix(1577865600, 0).UTC(), session.CreatedAt)
+		assert.Equal(t, ot, session.OpenTok)
+
+		_, err := session.GenerateToken(&TokenOptions{})
+		assert.Nil(t, err)
+	}
+
+	_, err = ot.SessionFromID("1_MX40MDAwMDAwMX5-MTU3Nzg2NTYwMDAwMH54N2I0OE1RZ0RmK1lRRnFQUWg4dlZmT0t-QX4")
+	assert.EqualError(t, err, "Session cannot be restored unless the session belongs to the API Key")
+
+	_, err = ot.SessionFromID("")
+	assert.NotNil(t, err)
+}
+
+func TestParseCreateDt(t *testing.T) {
+	actual, err := parseCreateDt("Wed Jan 01 00:00:00 PST 2020")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC), actual)
+
+	actual, err = parseCreateDt("Wed Jul 01 00:00:00 PDT 2020")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2020, 7, 1, 7, 0, 0, 0, time.UTC), actual)
+
+	_, err = parseCreateDt("2020-01-01")
+	assert.NotNil(t, err)
+}
+
+func TestOpenTok_CreateSession_Options(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, "always", r.PostForm.Get("archiveMode"))
+		assert.Equal(t, "disabled", r.PostForm.Get("p2p.preference"))
+		assert.Equal(t, "2001:db8::1", r.PostForm.Get("location"))
+		assert.Equal(t, "standup", r.PostForm.Get("archiveName"))
+		assert.Equal(t, "1280x720", r.PostForm.Get("archiveResolution"))
+		assert.Empty(t, r.PostForm.Get("e2ee"))
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"session_id": "1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", "project_id": "40000001"}]`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	_, err := ot.CreateSession(&SessionOptions{
+		ArchiveMode:       AutoArchived,
+		MediaMode:         Routed,
+		Location:          "2001:db8::1",
+		ArchiveName:       "standup",
+		ArchiveResolution: HDLandscape,
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestSessionOptions_Validate(t *testing.T) {
+	valid := []*SessionOptions{
+		{},
+		{Location: "12.34.56.78"},
+		{Location: "::1"},
+		{MediaMode: Routed, E2EE: true},
+		{MediaMode: Routed, ArchiveMode: ManualArchived, E2EE: true},
+		{MediaMode: Routed, ArchiveMode: AutoArchived, ArchiveName: "standup"},
+	}
+
+	for _, opts := range valid {
+		assert.Nil(t, opts.Validate(), "%+v", opts)
+	}
+
+	invalid := map[string]*SessionOptions{
+		"Invalid location for session creation, must be an IPv4 or IPv6 address: example.com": {Location: "example.com"},
+		"End-to-end encryption requires the routed media mode":                                {E2EE: true},
+		"End-to-end encryption cannot be used with automatic archiving":                       {MediaMode: Routed, ArchiveMode: AutoArchived, E2EE: true},
+		"Automatic archiving requires the routed media mode":                                  {MediaMode: Relayed, ArchiveMode: AutoArchived},
+		"Archive name and resolution can only be set for automatically archived sessions":     {MediaMode: Routed, ArchiveResolution: HDLandscape},
+	}
+
+	for message, opts := range invalid {
+		assert.EqualError(t, opts.Validate(), message)
+
+		_, err := ot.CreateSession(opts)
+		assert.EqualError(t, err, message)
+	}
+}
+
 func TestOpenTok_GenerateToken(t *testing.T) {
 	_, err := ot.GenerateToken("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &TokenOptions{
 		Role: Publisher,
@@ -106,7 +225,40 @@ func TestOpenTok_MuteSession(t *testing.T) {
 	assert.Nil(t, err)
 
 	if assert.NotNil(t, actual) {
-		assert.Equal(t, expect, actual)
+		assert.Equal(t, &MuteResult{
+			SessionID: "1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4",
+			Active:    true,
+			ExcludedStreams: []string{
+				"a919b531-bd0e-41fb-8ff0-cdc15684cc93",
+				"7f6d8780-741a-4824-98da-16c1f5f1f043",
+			},
+			Project: expect,
+		}, actual)
+	}
+}
+
+func TestOpenTok_DisableForceMute(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/mute"))
+
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"active": false}`, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "40000001", "status": "VALID"}`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	actual, err := ot.DisableForceMute("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4")
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.False(t, actual.Active)
+		assert.Empty(t, actual.ExcludedStreams)
+		assert.Equal(t, "40000001", actual.Project.ID)
 	}
 }
 
@@ -209,7 +361,70 @@ func TestSession_Mute(t *testing.T) {
 	assert.Nil(t, err)
 
 	if assert.NotNil(t, actual) {
-		assert.Equal(t, expect, actual)
+		assert.Equal(t, expect, actual.Project)
+		assert.True(t, actual.Active)
+	}
+
+	assert.Equal(t, &MuteState{
+		Active: true,
+		ExcludedStreams: []string{
+			"a919b531-bd0e-41fb-8ff0-cdc15684cc93",
+			"7f6d8780-741a-4824-98da-16c1f5f1f043",
+		},
+	}, session.MuteState)
+}
+
+func TestSession_MuteState(t *testing.T) {
+	bodies := []string{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "40000001", "status": "VALID"}`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	session := &Session{
+		SessionID: "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34",
+		ProjectID: apiKey,
+		OpenTok:   ot,
+	}
+
+	// Exclusions are only recorded locally, as pending.
+	session.ExcludeStreams("presenter-1")
+	assert.Empty(t, bodies)
+	assert.False(t, session.MuteState.IsExcluded("presenter-1"))
+	assert.Equal(t, []string{"presenter-1"}, session.MuteState.PendingExclusions)
+
+	_, err := session.Mute(&MuteOptions{Active: true})
+	assert.Nil(t, err)
+	assert.True(t, session.MuteState.IsExcluded("presenter-1"))
+	assert.Empty(t, session.MuteState.PendingExclusions)
+
+	// Excluding a new presenter stream does not mute the other streams again,
+	// and the stream is not reported as excluded until the mute is applied.
+	session.ExcludeStreams("presenter-2")
+	assert.True(t, session.MuteState.Active)
+	assert.False(t, session.MuteState.IsExcluded("presenter-2"))
+	assert.Len(t, bodies, 1)
+
+	_, err = session.DisableForceMute()
+	assert.Nil(t, err)
+	assert.False(t, session.MuteState.Active)
+	assert.True(t, session.MuteState.IsExcluded("presenter-1"))
+	assert.True(t, session.MuteState.IsExcluded("presenter-2"))
+
+	// The exclusions are sent when the force mute is applied again.
+	_, err = session.Mute(&MuteOptions{Active: true})
+	assert.Nil(t, err)
+
+	if assert.Len(t, bodies, 3) {
+		assert.JSONEq(t, `{"active": true, "excludedStreamIds": ["presenter-1"]}`, bodies[0])
+		assert.JSONEq(t, `{"active": false, "excludedStreamIds": ["presenter-1", "presenter-2"]}`, bodies[1])
+		assert.JSONEq(t, `{"active": true, "excludedStreamIds": ["presenter-1", "presenter-2"]}`, bodies[2])
 	}
 }
 
@@ -272,6 +487,11 @@ func TestDecodeSessionID(t *testing.T) {
 	if assert.NotEmpty(t, actual) {
 		assert.Equal(t, expect, actual)
 	}
+
+	for _, sessionID := range []string{"", "1", "1_", "1_Zm9v"} {
+		_, err := decodeSessionID(sessionID)
+		assert.NotNil(t, err, sessionID)
+	}
 }
 
 func TestEncodeToken(t *testing.T) {
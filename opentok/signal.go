@@ -8,6 +8,15 @@ import (
 	"net/http"
 )
 
+// The limits of a signal enforced by OpenTok.
+const (
+	// MaxSignalTypeLength is the maximum length of the signal type, in bytes.
+	MaxSignalTypeLength = 128
+
+	// MaxSignalDataLength is the maximum length of the signal data, in bytes.
+	MaxSignalDataLength = 8192
+)
+
 // SignalData defines the type and data of signal
 type SignalData struct {
 	// The type of the signal.
@@ -18,6 +27,51 @@ type SignalData struct {
 	Data string `json:"data"`
 }
 
+// NewJSONSignal returns a signal whose data is the JSON encoding of v.
+func NewJSONSignal(signalType string, v interface{}) (*SignalData, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	signal := &SignalData{
+		Type: signalType,
+		Data: string(data),
+	}
+
+	if err := signal.Validate(); err != nil {
+		return nil, err
+	}
+
+	return signal, nil
+}
+
+// Validate checks the signal against the limits of OpenTok. The type may only
+// contain letters, numbers, "-", "_" and "~".
+func (data *SignalData) Validate() error {
+	if len(data.Type) > MaxSignalTypeLength {
+		return fmt.Errorf("Invalid signal type, must have a maximum length of %d", MaxSignalTypeLength)
+	}
+
+	for _, r := range data.Type {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') &&
+			r != '-' && r != '_' && r != '~' {
+			return fmt.Errorf("Invalid signal type, contains invalid character %q", r)
+		}
+	}
+
+	if len(data.Data) > MaxSignalDataLength {
+		return fmt.Errorf("Invalid signal data, must have a maximum length of %d", MaxSignalDataLength)
+	}
+
+	return nil
+}
+
+// DecodeJSON decodes the JSON data of the signal into v.
+func (data *SignalData) DecodeJSON(v interface{}) error {
+	return json.Unmarshal([]byte(data.Data), v)
+}
+
 // SendSessionSignal send signals to all participants in an active OpenTok session.
 func (ot *OpenTok) SendSessionSignal(sessionID string, data *SignalData) error {
 	return ot.SendSessionSignalContext(context.Background(), sessionID, data)
@@ -29,6 +83,14 @@ func (ot *OpenTok) SendSessionSignalContext(ctx context.Context, sessionID strin
 		return fmt.Errorf("Signal cannot be sent without a session ID")
 	}
 
+	if data == nil {
+		return fmt.Errorf("Signal cannot be sent without signal data")
+	}
+
+	if err := data.Validate(); err != nil {
+		return err
+	}
+
 	jsonStr, _ := json.Marshal(data)
 
 	// Create jwt token
@@ -74,6 +136,14 @@ func (ot *OpenTok) SendConnectionSignalContext(ctx context.Context, sessionID, c
 		return fmt.Errorf("Signal cannot be sent without a connection ID")
 	}
 
+	if data == nil {
+		return fmt.Errorf("Signal cannot be sent without signal data")
+	}
+
+	if err := data.Validate(); err != nil {
+		return err
+	}
+
 	jsonStr, _ := json.Marshal(data)
 
 	// Create jwt token
@@ -104,6 +174,65 @@ func (ot *OpenTok) SendConnectionSignalContext(ctx context.Context, sessionID, c
 	return nil
 }
 
+// SendSignalToConnections sends a signal to each of the connections in an
+// active OpenTok session, in parallel. It does not stop at the first failure:
+// the result maps each connection ID to its error (nil on success), and the
+// returned error is a *FanOutError if any signal failed.
+func (ot *OpenTok) SendSignalToConnections(sessionID string, connectionIDs []string, data *SignalData, opts *FanOutOptions) (map[string]error, error) {
+	return ot.SendSignalToConnectionsContext(context.Background(), sessionID, connectionIDs, data, opts)
+}
+
+// SendSignalToConnectionsContext uses ctx for HTTP requests.
+func (ot *OpenTok) SendSignalToConnectionsContext(ctx context.Context, sessionID string, connectionIDs []string, data *SignalData, opts *FanOutOptions) (map[string]error, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("Signal cannot be sent without a session ID")
+	}
+
+	if data == nil {
+		return nil, fmt.Errorf("Signal cannot be sent without signal data")
+	}
+
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	return fanOut(ctx, connectionIDs, opts, func(ctx context.Context, connectionID string) error {
+		return ot.SendConnectionSignalContext(ctx, sessionID, connectionID, data)
+	})
+}
+
+// SendJSONSignal sends a signal whose data is the JSON encoding of v to all
+// participants in an active OpenTok session.
+func (ot *OpenTok) SendJSONSignal(sessionID, signalType string, v interface{}) error {
+	return ot.SendJSONSignalContext(context.Background(), sessionID, signalType, v)
+}
+
+// SendJSONSignalContext uses ctx for HTTP requests.
+func (ot *OpenTok) SendJSONSignalContext(ctx context.Context, sessionID, signalType string, v interface{}) error {
+	data, err := NewJSONSignal(signalType, v)
+	if err != nil {
+		return err
+	}
+
+	return ot.SendSessionSignalContext(ctx, sessionID, data)
+}
+
+// SendJSONConnectionSignal sends a signal whose data is the JSON encoding of v
+// to a specific client in an active OpenTok session.
+func (ot *OpenTok) SendJSONConnectionSignal(sessionID, connectionID, signalType string, v interface{}) error {
+	return ot.SendJSONConnectionSignalContext(context.Background(), sessionID, connectionID, signalType, v)
+}
+
+// SendJSONConnectionSignalContext uses ctx for HTTP requests.
+func (ot *OpenTok) SendJSONConnectionSignalContext(ctx context.Context, sessionID, connectionID, signalType string, v interface{}) error {
+	data, err := NewJSONSignal(signalType, v)
+	if err != nil {
+		return err
+	}
+
+	return ot.SendConnectionSignalContext(ctx, sessionID, connectionID, data)
+}
+
 // SendSignal send signals to all participants.
 func (s *Session) SendSignal(data *SignalData) error {
 	return s.SendSignalContext(context.Background(), data)
@@ -1,8 +1,10 @@
 package opentok
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -87,3 +89,95 @@ func TestSession_SendConnectionSignal(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestOpenTok_SendJSONSignal(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		data := &SignalData{}
+		json.NewDecoder(r.Body).Decode(data)
+		assert.Equal(t, "chat", data.Type)
+		assert.JSONEq(t, `{"from": "alice", "text": "hello"}`, data.Data)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	err := ot.SendJSONSignal("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", "chat", map[string]string{
+		"from": "alice",
+		"text": "hello",
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestSignalData_Validate(t *testing.T) {
+	assert.Nil(t, (&SignalData{Type: "chat-message_v2~", Data: "hello"}).Validate())
+	assert.Nil(t, (&SignalData{Data: "hello"}).Validate())
+	assert.NotNil(t, (&SignalData{Type: "chat message"}).Validate())
+	assert.NotNil(t, (&SignalData{Type: strings.Repeat("a", MaxSignalTypeLength+1)}).Validate())
+	assert.NotNil(t, (&SignalData{Type: "chat", Data: strings.Repeat("a", MaxSignalDataLength+1)}).Validate())
+}
+
+func TestOpenTok_SendSessionSignal_TooLarge(t *testing.T) {
+	err := ot.SendSessionSignal("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &SignalData{
+		Type: "foo",
+		Data: strings.Repeat("a", MaxSignalDataLength+1),
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestSignalData_DecodeJSON(t *testing.T) {
+	type reaction struct {
+		Emoji string `json:"emoji"`
+		Count int    `json:"count"`
+	}
+
+	signal, err := NewJSONSignal("reaction", &reaction{Emoji: "clap", Count: 3})
+
+	if assert.Nil(t, err) {
+		actual := &reaction{}
+
+		assert.Nil(t, signal.DecodeJSON(actual))
+		assert.Equal(t, &reaction{Emoji: "clap", Count: 3}, actual)
+	}
+}
+
+func TestOpenTok_SendSignalToConnections(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		if strings.Contains(r.URL.Path, "/connection/gone/") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not found. The client specified by the connectionId property is not connected to the session."}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	results, err := ot.SendSignalToConnections("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", []string{
+		"18145975-97c8-4802-8975-fc8408d56d5c",
+		"gone",
+		"3a6aa409-bfc5-462c-a9c7-59b72aeebf69",
+	}, &SignalData{
+		Type: "breakout",
+		Data: "room-2",
+	}, &FanOutOptions{
+		Concurrency: 2,
+	})
+
+	assert.Len(t, results, 3)
+	assert.Nil(t, results["18145975-97c8-4802-8975-fc8408d56d5c"])
+	assert.Nil(t, results["3a6aa409-bfc5-462c-a9c7-59b72aeebf69"])
+
+	if assert.IsType(t, &ResponseError{}, results["gone"]) {
+		assert.Equal(t, 404, results["gone"].(*ResponseError).StatusCode)
+	}
+
+	assert.IsType(t, &FanOutError{}, err)
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"regexp"
 )
 
 // SIPHeaders is the alias of map[string]string type
@@ -42,6 +41,17 @@ type SIP struct {
 	// A flag that indicates whether the media must be transmitted encrypted
 	// or not.
 	Secure bool `json:"secure,omitempty"`
+
+	// A flag that indicates whether the SIP call will include video or not.
+	Video bool `json:"video,omitempty"`
+
+	// A flag that indicates whether the SIP end point observes force mute
+	// moderation events or not.
+	ObserveForceMute bool `json:"observeForceMute,omitempty"`
+
+	// The stream IDs of the streams to include in the SIP call. If empty, all
+	// streams in the session are included.
+	Streams []string `json:"streams,omitempty"`
 }
 
 // DialOptions defines the options for SIP call
@@ -57,6 +67,11 @@ type DialOptions struct {
 
 	// The data for token generation
 	TokenData string `json:"-"`
+
+	// The options for token generation, such as the role and the initial
+	// layout classes of the SIP participant. TokenData is used as the data
+	// if the options do not set it.
+	TokenOptions *TokenOptions `json:"-"`
 }
 
 // SIPCall defines the response returned from API
@@ -86,13 +101,24 @@ func (ot *OpenTok) DialContext(ctx context.Context, sessionID string, opts *Dial
 		return nil, fmt.Errorf("SIP call cannot be initiated without a session ID")
 	}
 
-	if opts.SIP.URI == "" {
+	if opts == nil || opts.SIP == nil || opts.SIP.URI == "" {
 		return nil, fmt.Errorf("SIP call cannot be initiated without a SIP URI")
 	}
 
-	token, err := ot.GenerateToken(sessionID, &TokenOptions{
-		Data: opts.TokenData,
-	})
+	if err := validateSIP(opts.SIP); err != nil {
+		return nil, err
+	}
+
+	tokenOpts := &TokenOptions{}
+	if opts.TokenOptions != nil {
+		*tokenOpts = *opts.TokenOptions
+	}
+
+	if tokenOpts.Data == "" {
+		tokenOpts.Data = opts.TokenData
+	}
+
+	token, err := ot.GenerateToken(sessionID, tokenOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -146,16 +172,11 @@ func (ot *OpenTok) SendDTMFContext(ctx context.Context, sessionID, digits string
 		return fmt.Errorf("DTMF digits cannot be sent without a session ID")
 	}
 
-	if digits == "" {
-		return fmt.Errorf("The DTMF digits cannot be empty")
-	}
-
-	match, err := regexp.MatchString(`^[\d#*p]+$`, digits)
-	if !match || err != nil {
-		return fmt.Errorf("The DTMF digits is invalid")
+	if err := DTMFSequence(digits).Validate(); err != nil {
+		return err
 	}
 
-	jsonStr := []byte(`{ "digits": "` + digits + `" }`)
+	jsonStr, _ := json.Marshal(map[string]string{"digits": digits})
 
 	// Create jwt token
 	jwt, err := ot.genProjectJWT()
@@ -200,16 +221,11 @@ func (ot *OpenTok) SendDTMFToClientContext(ctx context.Context, sessionID, conne
 		return fmt.Errorf("DTMF digits cannot be sent without a connection ID")
 	}
 
-	if digits == "" {
-		return fmt.Errorf("The DTMF digits cannot be empty")
-	}
-
-	match, err := regexp.MatchString(`^[\d#*p]+$`, digits)
-	if !match || err != nil {
-		return fmt.Errorf("The DTMF digits is invalid")
+	if err := DTMFSequence(digits).Validate(); err != nil {
+		return err
 	}
 
-	jsonStr := []byte(`{ "digits": "` + digits + `" }`)
+	jsonStr, _ := json.Marshal(map[string]string{"digits": digits})
 
 	// Create jwt token
 	jwt, err := ot.genProjectJWT()
@@ -0,0 +1,206 @@
+package opentok
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SIPCallManagerOptions defines the options for managing SIP calls.
+type SIPCallManagerOptions struct {
+	// Calls lasting longer than this are hung up. Zero disables it.
+	MaxCallDuration time.Duration
+
+	// OnHangupError is called when hanging up a call at the maximum call
+	// duration fails.
+	OnHangupError func(call *ManagedSIPCall, err error)
+}
+
+// ManagedSIPCall defines a SIP call tracked by a SIPCallManager.
+type ManagedSIPCall struct {
+	*SIPCall
+
+	// The OpenTok session ID of the call.
+	SessionID string
+
+	// The time at which the call was dialed.
+	StartedAt time.Time
+
+	timer *time.Timer
+}
+
+// SIPCallManager tracks the SIP calls dialed into sessions and acts on them
+// by their call ID.
+type SIPCallManager struct {
+	ot   *OpenTok
+	opts SIPCallManagerOptions
+
+	mu    sync.Mutex
+	calls map[string]*ManagedSIPCall
+}
+
+// NewSIPCallManager returns a SIP call manager.
+func (ot *OpenTok) NewSIPCallManager(opts *SIPCallManagerOptions) *SIPCallManager {
+	m := &SIPCallManager{
+		ot:    ot,
+		calls: map[string]*ManagedSIPCall{},
+	}
+
+	if opts != nil {
+		m.opts = *opts
+	}
+
+	return m
+}
+
+// Dial connects your SIP platform to an OpenTok session and tracks the call.
+func (m *SIPCallManager) Dial(sessionID string, opts *DialOptions) (*ManagedSIPCall, error) {
+	return m.DialContext(context.Background(), sessionID, opts)
+}
+
+// DialContext uses ctx for HTTP requests.
+func (m *SIPCallManager) DialContext(ctx context.Context, sessionID string, opts *DialOptions) (*ManagedSIPCall, error) {
+	sipCall, err := m.ot.DialContext(ctx, sessionID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	call := &ManagedSIPCall{
+		SIPCall:   sipCall,
+		SessionID: sessionID,
+		StartedAt: time.Now(),
+	}
+
+	m.mu.Lock()
+	m.calls[call.ID] = call
+	if m.opts.MaxCallDuration > 0 {
+		call.timer = time.AfterFunc(m.opts.MaxCallDuration, func() {
+			if err := m.Hangup(call.ID); err != nil && m.opts.OnHangupError != nil {
+				m.opts.OnHangupError(call, err)
+			}
+		})
+	}
+	m.mu.Unlock()
+
+	return call, nil
+}
+
+// Get returns a tracked call.
+func (m *SIPCallManager) Get(callID string) (*ManagedSIPCall, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	call, ok := m.calls[callID]
+
+	return call, ok
+}
+
+// List returns the tracked calls of a session, from the oldest to the newest.
+// If sessionID is empty, the calls of all sessions are returned.
+func (m *SIPCallManager) List(sessionID string) []*ManagedSIPCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := []*ManagedSIPCall{}
+	for _, call := range m.calls {
+		if sessionID == "" || call.SessionID == sessionID {
+			calls = append(calls, call)
+		}
+	}
+
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].StartedAt.Before(calls[j].StartedAt)
+	})
+
+	return calls
+}
+
+// Forget stops tracking a call without hanging it up, for example after
+// receiving a callback that the call ended.
+func (m *SIPCallManager) Forget(callID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if call, ok := m.calls[callID]; ok {
+		if call.timer != nil {
+			call.timer.Stop()
+		}
+
+		delete(m.calls, callID)
+	}
+}
+
+// Hangup disconnects the call from its session and stops tracking it. A call
+// that has already ended is only forgotten.
+func (m *SIPCallManager) Hangup(callID string) error {
+	return m.HangupContext(context.Background(), callID)
+}
+
+// HangupContext uses ctx for HTTP requests.
+func (m *SIPCallManager) HangupContext(ctx context.Context, callID string) error {
+	call, err := m.lookup(callID)
+	if err != nil {
+		return err
+	}
+
+	if err := m.ot.ForceDisconnectContext(ctx, call.SessionID, call.ConnectionID); err != nil {
+		// The call is no longer connected to the session.
+		var resErr *ResponseError
+		if !errors.As(err, &resErr) || resErr.StatusCode != 404 {
+			return err
+		}
+	}
+
+	m.Forget(callID)
+
+	return nil
+}
+
+// SendDTMF sends the DTMF digits to the call only.
+func (m *SIPCallManager) SendDTMF(callID string, digits DTMFSequence) error {
+	return m.SendDTMFContext(context.Background(), callID, digits)
+}
+
+// SendDTMFContext uses ctx for HTTP requests.
+func (m *SIPCallManager) SendDTMFContext(ctx context.Context, callID string, digits DTMFSequence) error {
+	call, err := m.lookup(callID)
+	if err != nil {
+		return err
+	}
+
+	return m.ot.SendDTMFToClientContext(ctx, call.SessionID, call.ConnectionID, string(digits))
+}
+
+// Mute forces the stream of the call to mute its audio.
+func (m *SIPCallManager) Mute(callID string) error {
+	return m.MuteContext(context.Background(), callID)
+}
+
+// MuteContext uses ctx for HTTP requests.
+func (m *SIPCallManager) MuteContext(ctx context.Context, callID string) error {
+	call, err := m.lookup(callID)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.ot.MuteStreamContext(ctx, call.SessionID, call.StreamID)
+
+	return err
+}
+
+// Find a tracked call.
+func (m *SIPCallManager) lookup(callID string) (*ManagedSIPCall, error) {
+	if callID == "" {
+		return nil, fmt.Errorf("Cannot find a SIP call without a call ID")
+	}
+
+	call, ok := m.Get(callID)
+	if !ok {
+		return nil, fmt.Errorf("SIP call %s is not tracked", callID)
+	}
+
+	return call, nil
+}
@@ -0,0 +1,145 @@
+package opentok
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSIPCallManagerTestServer(requests chan<- string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.Method + " " + r.URL.Path
+
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`
+				{
+					"id": "ab31819a-cd52-4da4-8b3e-fb9803337c17",
+					"connectionId": "3a6aa409-bfc5-462c-a9c7-59b72aeebf69",
+					"streamId": "f1a58131-7b2c-4fa8-b2a7-64fdc6b2c0f6"
+				}
+			`))
+		}
+	}))
+}
+
+func TestSIPCallManager(t *testing.T) {
+	const sessionID = "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34"
+
+	requests := make(chan string, 10)
+	ts := newSIPCallManagerTestServer(requests)
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	manager := ot.NewSIPCallManager(nil)
+
+	call, err := manager.Dial(sessionID, &DialOptions{
+		SIP: &SIP{
+			URI: "sip:user@sip.example.com",
+		},
+	})
+
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "POST /v2/project/"+apiKey+"/dial", <-requests)
+	assert.Equal(t, sessionID, call.SessionID)
+	assert.Equal(t, []*ManagedSIPCall{call}, manager.List(sessionID))
+	assert.Empty(t, manager.List("2_MX4xMDB-flR1-QxNzIxNX4"))
+
+	assert.Nil(t, manager.SendDTMF(call.ID, "1234#"))
+	assert.Equal(t, "POST /v2/project/"+apiKey+"/session/"+sessionID+"/connection/3a6aa409-bfc5-462c-a9c7-59b72aeebf69/play-dtmf", <-requests)
+
+	assert.Nil(t, manager.Mute(call.ID))
+	assert.Equal(t, "POST /v2/project/"+apiKey+"/session/"+sessionID+"/stream/f1a58131-7b2c-4fa8-b2a7-64fdc6b2c0f6/mute", <-requests)
+
+	assert.Nil(t, manager.Hangup(call.ID))
+	assert.Equal(t, "DELETE /v2/project/"+apiKey+"/session/"+sessionID+"/connection/3a6aa409-bfc5-462c-a9c7-59b72aeebf69", <-requests)
+	assert.Empty(t, manager.List(""))
+
+	assert.NotNil(t, manager.Hangup(call.ID))
+}
+
+func TestSIPCallManager_MaxCallDuration(t *testing.T) {
+	requests := make(chan string, 10)
+	ts := newSIPCallManagerTestServer(requests)
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	var (
+		mu        sync.Mutex
+		hangupErr error
+	)
+
+	manager := ot.NewSIPCallManager(&SIPCallManagerOptions{
+		MaxCallDuration: 10 * time.Millisecond,
+		OnHangupError: func(call *ManagedSIPCall, err error) {
+			mu.Lock()
+			hangupErr = err
+			mu.Unlock()
+		},
+	})
+
+	_, err := manager.Dial("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &DialOptions{
+		SIP: &SIP{
+			URI: "sip:user@sip.example.com",
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Contains(t, <-requests, "/dial")
+
+	select {
+	case request := <-requests:
+		assert.Contains(t, request, "DELETE ")
+	case <-time.After(5 * time.Second):
+		t.Fatal("call was not hung up")
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(manager.List("")) == 0
+	}, time.Second, 5*time.Millisecond)
+
+	mu.Lock()
+	assert.Nil(t, hangupErr)
+	mu.Unlock()
+}
+
+func TestSIPCallManager_HangupEnded(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": 404, "message": "Not found. The connection does not exist."}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "ab31819a-cd52-4da4-8b3e-fb9803337c17", "connectionId": "3a6aa409-bfc5-462c-a9c7-59b72aeebf69"}`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	manager := ot.NewSIPCallManager(nil)
+
+	call, err := manager.Dial("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &DialOptions{
+		SIP: &SIP{
+			URI: "sip:user@sip.example.com",
+		},
+	})
+
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// The call has already ended, so it is only forgotten.
+	assert.Nil(t, manager.Hangup(call.ID))
+	assert.Empty(t, manager.List(""))
+}
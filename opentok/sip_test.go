@@ -1,8 +1,11 @@
 package opentok
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -60,7 +63,8 @@ func TestOpenTok_SendDTMF(t *testing.T) {
 	defer ts.Close()
 	ot.SetAPIHost(ts.URL)
 
-	err := ot.SendDTMF("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", "1713")
+	digits := "1713"
+	err := ot.SendDTMF("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", digits)
 
 	assert.Nil(t, err)
 }
@@ -128,6 +132,12 @@ func TestSession_Dial(t *testing.T) {
 	}
 }
 
+func TestOpenTok_SendDTMF_Invalid(t *testing.T) {
+	err := ot.SendDTMF("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", `1" }`)
+
+	assert.NotNil(t, err)
+}
+
 func TestSession_SendDTMF(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, http.MethodPost, r.Method)
@@ -167,3 +177,61 @@ func TestSession_SendDTMFToClient(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestOpenTok_Dial_VideoAndTokenOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		body := struct {
+			Token string `json:"token"`
+			SIP   *SIP   `json:"sip"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		assert.True(t, body.SIP.Video)
+		assert.True(t, body.SIP.ObserveForceMute)
+		assert.Equal(t, []string{"f1a58131-7b2c-4fa8-b2a7-64fdc6b2c0f6"}, body.SIP.Streams)
+
+		decoded, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(body.Token, tokenSentinel))
+		assert.Contains(t, string(decoded), "role=moderator")
+		assert.Contains(t, string(decoded), "connection_data=room-hardware")
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`
+			{
+				"id": "ab31819a-cd52-4da4-8b3e-fb9803337c17",
+				"connectionId": "3a6aa409-bfc5-462c-a9c7-59b72aeebf69",
+				"streamId": "f1a58131-7b2c-4fa8-b2a7-64fdc6b2c0f6"
+			}
+		`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	_, err := ot.Dial("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &DialOptions{
+		SIP: &SIP{
+			URI:              "sip:user@sip.example.com;transport=tls",
+			Video:            true,
+			ObserveForceMute: true,
+			Streams:          []string{"f1a58131-7b2c-4fa8-b2a7-64fdc6b2c0f6"},
+		},
+		TokenData: "room-hardware",
+		TokenOptions: &TokenOptions{
+			Role: Moderator,
+		},
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestOpenTok_Dial_InvalidHeaderName(t *testing.T) {
+	_, err := ot.Dial("1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", &DialOptions{
+		SIP: &SIP{
+			URI: "sip:user@sip.example.com;transport=tls",
+			Headers: &SIPHeaders{
+				"Foo": "bar",
+			},
+		},
+	})
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,178 @@
+package opentok
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The characters allowed in a host name.
+var sipHostPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9.-]*[A-Za-z0-9])?$`)
+
+// SIPURI defines the parts of a SIP URI, such as
+// "sips:alice:secret@example.com:5061;transport=tls".
+type SIPURI struct {
+	// Either "sip" or "sips".
+	Scheme string
+
+	// The user part, if any.
+	User string
+
+	// The password part, if any.
+	Password string
+
+	// The host name or IP address. IPv6 addresses are not enclosed in
+	// brackets.
+	Host string
+
+	// The port, or zero if not specified.
+	Port int
+
+	// The URI parameters, with lowercase names. Parameters without a value
+	// map to an empty string.
+	Params map[string]string
+}
+
+// Transport returns the lowercase value of the transport parameter.
+func (u *SIPURI) Transport() string {
+	return strings.ToLower(u.Params["transport"])
+}
+
+// IsTLS reports whether the URI requires TLS, either with the sips scheme or
+// with the transport=tls parameter.
+func (u *SIPURI) IsTLS() bool {
+	return u.Scheme == "sips" || u.Transport() == "tls"
+}
+
+// ParseSIPURI parses a sip: or sips: URI.
+func ParseSIPURI(uri string) (*SIPURI, error) {
+	if uri == "" {
+		return nil, fmt.Errorf("Invalid SIP URI: empty URI")
+	}
+
+	for _, r := range uri {
+		if r <= ' ' || r == 0x7f {
+			return nil, fmt.Errorf("Invalid SIP URI %q: contains whitespace or control characters", uri)
+		}
+	}
+
+	colon := strings.Index(uri, ":")
+	if colon < 0 {
+		return nil, fmt.Errorf("Invalid SIP URI %q: missing scheme", uri)
+	}
+
+	u := &SIPURI{
+		Scheme: strings.ToLower(uri[:colon]),
+		Params: map[string]string{},
+	}
+
+	if u.Scheme != "sip" && u.Scheme != "sips" {
+		return nil, fmt.Errorf("Invalid SIP URI %q: scheme must be sip or sips", uri)
+	}
+
+	rest := uri[colon+1:]
+
+	// Drop the URI headers.
+	if i := strings.Index(rest, "?"); i >= 0 {
+		rest = rest[:i]
+	}
+
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		userinfo := rest[:i]
+		rest = rest[i+1:]
+
+		if j := strings.Index(userinfo, ":"); j >= 0 {
+			u.User, u.Password = userinfo[:j], userinfo[j+1:]
+		} else {
+			u.User = userinfo
+		}
+
+		if u.User == "" {
+			return nil, fmt.Errorf("Invalid SIP URI %q: empty user", uri)
+		}
+	}
+
+	params := strings.Split(rest, ";")
+	hostport := params[0]
+
+	for _, param := range params[1:] {
+		if param == "" {
+			continue
+		}
+
+		if i := strings.Index(param, "="); i >= 0 {
+			u.Params[strings.ToLower(param[:i])] = param[i+1:]
+		} else {
+			u.Params[strings.ToLower(param)] = ""
+		}
+	}
+
+	host, port := hostport, ""
+	if strings.HasPrefix(hostport, "[") {
+		end := strings.Index(hostport, "]")
+		if end < 0 {
+			return nil, fmt.Errorf("Invalid SIP URI %q: unterminated IPv6 address", uri)
+		}
+
+		host = hostport[1:end]
+		if net.ParseIP(host) == nil || !strings.Contains(host, ":") {
+			return nil, fmt.Errorf("Invalid SIP URI %q: invalid IPv6 address", uri)
+		}
+
+		port = strings.TrimPrefix(hostport[end+1:], ":")
+		if hostport[end+1:] != "" && !strings.HasPrefix(hostport[end+1:], ":") {
+			return nil, fmt.Errorf("Invalid SIP URI %q: invalid host", uri)
+		}
+	} else {
+		if i := strings.Index(hostport, ":"); i >= 0 {
+			host, port = hostport[:i], hostport[i+1:]
+		}
+
+		if !sipHostPattern.MatchString(host) {
+			return nil, fmt.Errorf("Invalid SIP URI %q: invalid host", uri)
+		}
+	}
+
+	u.Host = host
+
+	if port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil || p < 1 || p > 65535 {
+			return nil, fmt.Errorf("Invalid SIP URI %q: invalid port", uri)
+		}
+
+		u.Port = p
+	}
+
+	return u, nil
+}
+
+// Validate the SIP information before initiating a call.
+func validateSIP(sip *SIP) error {
+	uri, err := ParseSIPURI(sip.URI)
+	if err != nil {
+		return err
+	}
+
+	if sip.Secure && !uri.IsTLS() {
+		return fmt.Errorf("Invalid SIP URI %q: secure media requires the sips scheme or TLS transport", sip.URI)
+	}
+
+	if sip.Headers != nil {
+		for name, value := range *sip.Headers {
+			if !strings.HasPrefix(strings.ToUpper(name), "X-") {
+				return fmt.Errorf("Invalid SIP header name %q, custom headers must start with X-", name)
+			}
+
+			for _, r := range value {
+				if (r < ' ' && r != '\t') || r == 0x7f {
+					return fmt.Errorf("Invalid SIP header %q, value contains control characters", name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package opentok
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSIPURI(t *testing.T) {
+	expect := &SIPURI{
+		Scheme:   "sip",
+		User:     "alice",
+		Password: "secret",
+		Host:     "sip.example.com",
+		Port:     5061,
+		Params: map[string]string{
+			"transport": "TLS",
+			"lr":        "",
+		},
+	}
+
+	actual, err := ParseSIPURI("SIP:alice:secret@sip.example.com:5061;transport=TLS;lr?subject=meeting")
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, expect, actual)
+		assert.Equal(t, "tls", actual.Transport())
+		assert.True(t, actual.IsTLS())
+	}
+}
+
+func TestParseSIPURI_IPv6(t *testing.T) {
+	actual, err := ParseSIPURI("sips:[2001:db8::1]:5061")
+
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, actual) {
+		assert.Equal(t, "2001:db8::1", actual.Host)
+		assert.Equal(t, 5061, actual.Port)
+		assert.True(t, actual.IsTLS())
+	}
+}
+
+func TestParseSIPURI_Invalid(t *testing.T) {
+	uris := []string{
+		"",
+		"user@sip.example.com",
+		"tel:+15551234567",
+		"sip:@sip.example.com",
+		"sip:user@",
+		"sip:user@sip.example.com:0",
+		"sip:user@sip.example.com:70000",
+		"sip:user@sip example.com",
+		"sip:user@sip.example.com;transport=tls\r\nX-Injected: 1",
+		"sip:user@[2001:db8::1",
+		"sip:user@-example.com",
+	}
+
+	for _, uri := range uris {
+		_, err := ParseSIPURI(uri)
+
+		assert.NotNil(t, err, uri)
+	}
+}
+
+func TestValidateSIP(t *testing.T) {
+	assert.Nil(t, validateSIP(&SIP{
+		URI:    "sip:user@sip.example.com;transport=tls",
+		Secure: true,
+		Headers: &SIPHeaders{
+			"X-Foo": "bar",
+		},
+	}))
+
+	assert.NotNil(t, validateSIP(&SIP{
+		URI:    "sip:user@sip.example.com",
+		Secure: true,
+	}))
+
+	assert.NotNil(t, validateSIP(&SIP{
+		URI: "sip:user@sip.example.com",
+		Headers: &SIPHeaders{
+			"X-Foo": "bar\r\nX-Injected: 1",
+		},
+	}))
+}
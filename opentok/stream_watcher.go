@@ -0,0 +1,198 @@
+package opentok
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StreamEventType is the alias of string type.
+type StreamEventType string
+
+const (
+	// StreamCreated is emitted when a stream is published to the session.
+	StreamCreated StreamEventType = "streamCreated"
+
+	// StreamDestroyed is emitted when a stream leaves the session.
+	StreamDestroyed StreamEventType = "streamDestroyed"
+
+	// StreamChanged is emitted when the name, video type or layout classes of
+	// a stream change.
+	StreamChanged StreamEventType = "streamChanged"
+
+	// StreamWatcherError is emitted when polling the streams fails.
+	StreamWatcherError StreamEventType = "error"
+)
+
+// StreamWatcherOptions defines the options for watching the streams of a
+// session.
+type StreamWatcherOptions struct {
+	// The polling interval, 5 seconds by default.
+	Interval time.Duration
+}
+
+// StreamEvent defines an event emitted by the stream watcher.
+type StreamEvent struct {
+	// The type of the event.
+	Type StreamEventType
+
+	// The session ID.
+	SessionID string
+
+	// The stream, as of the latest poll. For StreamDestroyed events, it is the
+	// last known state of the stream.
+	Stream *Stream
+
+	// The previous state of the stream, for StreamChanged events.
+	Previous *Stream
+
+	// The error, for error events.
+	Err error
+
+	// The time at which the event was detected.
+	Time time.Time
+}
+
+// StreamWatcher polls the streams of a session and emits events when streams
+// are created, destroyed or changed.
+type StreamWatcher struct {
+	ot        *OpenTok
+	sessionID string
+	opts      StreamWatcherOptions
+
+	streams map[string]*Stream
+	events  chan *StreamEvent
+}
+
+// NewStreamWatcher returns a stream watcher for a session. Call Run to start
+// polling.
+func (ot *OpenTok) NewStreamWatcher(sessionID string, opts *StreamWatcherOptions) *StreamWatcher {
+	w := &StreamWatcher{
+		ot:        ot,
+		sessionID: sessionID,
+		streams:   map[string]*Stream{},
+		events:    make(chan *StreamEvent, 16),
+	}
+
+	if opts != nil {
+		w.opts = *opts
+	}
+
+	if w.opts.Interval <= 0 {
+		w.opts.Interval = 5 * time.Second
+	}
+
+	return w
+}
+
+// Events returns the channel on which events are emitted. It is closed when
+// Run returns.
+func (w *StreamWatcher) Events() <-chan *StreamEvent {
+	return w.events
+}
+
+// Run polls the streams of the session at the configured interval until ctx
+// is done. The streams found by the first poll are reported as created.
+func (w *StreamWatcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	if w.sessionID == "" {
+		return fmt.Errorf("Cannot watch streams without a session ID")
+	}
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll the streams once and emit events for the differences with the previous
+// poll. It only returns an error if ctx is done.
+func (w *StreamWatcher) poll(ctx context.Context) error {
+	list, err := w.ot.ListStreamsContext(ctx, w.sessionID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		return w.emit(ctx, &StreamEvent{Type: StreamWatcherError, Err: err})
+	}
+
+	now := time.Now()
+	events := []*StreamEvent{}
+	current := make(map[string]*Stream, len(list.Items))
+
+	// Streams are listed from the newest to the oldest, so walk them backwards
+	// to report creations in order.
+	for i := len(list.Items) - 1; i >= 0; i-- {
+		stream := list.Items[i]
+		current[stream.ID] = stream
+
+		previous, ok := w.streams[stream.ID]
+		switch {
+		case !ok:
+			events = append(events, &StreamEvent{Type: StreamCreated, Stream: stream})
+		case streamChanged(previous, stream):
+			events = append(events, &StreamEvent{Type: StreamChanged, Stream: stream, Previous: previous})
+		}
+	}
+
+	for id, stream := range w.streams {
+		if _, ok := current[id]; !ok {
+			events = append(events, &StreamEvent{Type: StreamDestroyed, Stream: stream})
+		}
+	}
+
+	w.streams = current
+
+	for _, event := range events {
+		event.Time = now
+
+		if err := w.emit(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Send an event unless ctx is done.
+func (w *StreamWatcher) emit(ctx context.Context, event *StreamEvent) error {
+	event.SessionID = w.sessionID
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	select {
+	case w.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Report whether the name, video type or layout classes of a stream differ.
+func streamChanged(a, b *Stream) bool {
+	if a.Name != b.Name || a.VideoType != b.VideoType || len(a.LayoutClassList) != len(b.LayoutClassList) {
+		return true
+	}
+
+	for i := range a.LayoutClassList {
+		if a.LayoutClassList[i] != b.LayoutClassList[i] {
+			return true
+		}
+	}
+
+	return false
+}
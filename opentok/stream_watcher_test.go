@@ -0,0 +1,113 @@
+package opentok
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamWatcher_Run(t *testing.T) {
+	snapshots := []string{
+		`{"count": 2, "items": [
+			{"id": "stream-2", "videoType": "camera", "name": "bob", "layoutClassList": []},
+			{"id": "stream-1", "videoType": "camera", "name": "alice", "layoutClassList": []}
+		]}`,
+		`{"count": 2, "items": [
+			{"id": "stream-2", "videoType": "camera", "name": "bob", "layoutClassList": ["focus"]},
+			{"id": "stream-1", "videoType": "camera", "name": "alice", "layoutClassList": []}
+		]}`,
+		`{"count": 1, "items": [
+			{"id": "stream-2", "videoType": "camera", "name": "bob", "layoutClassList": ["focus"]}
+		]}`,
+	}
+
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		mu.Lock()
+		snapshot := snapshots[len(snapshots)-1]
+		if polls < len(snapshots) {
+			snapshot = snapshots[polls]
+		}
+		polls++
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(snapshot))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	watcher := ot.NewStreamWatcher("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &StreamWatcherOptions{
+		Interval: 10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		done <- watcher.Run(ctx)
+	}()
+
+	events := []*StreamEvent{}
+	for event := range watcher.Events() {
+		events = append(events, event)
+
+		if event.Type == StreamDestroyed {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, context.Canceled, <-done)
+
+	if assert.Len(t, events, 4) {
+		assert.Equal(t, StreamCreated, events[0].Type)
+		assert.Equal(t, "stream-1", events[0].Stream.ID)
+		assert.Equal(t, StreamCreated, events[1].Type)
+		assert.Equal(t, "stream-2", events[1].Stream.ID)
+
+		assert.Equal(t, StreamChanged, events[2].Type)
+		assert.Equal(t, []string{}, events[2].Previous.LayoutClassList)
+		assert.Equal(t, []string{"focus"}, events[2].Stream.LayoutClassList)
+
+		assert.Equal(t, StreamDestroyed, events[3].Type)
+		assert.Equal(t, "stream-1", events[3].Stream.ID)
+	}
+}
+
+func TestStreamWatcher_Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "Authentication failed."}`))
+	}))
+	defer ts.Close()
+	ot.SetAPIHost(ts.URL)
+
+	watcher := ot.NewStreamWatcher("1_QX90NjQ2MCY0Nm6-MTU4QTO4NzE5NTkyOX4yUy2OZndKQExJR0NyalcvNktmTzBpSnp-QX4", &StreamWatcherOptions{
+		Interval: 10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go watcher.Run(ctx)
+
+	event := <-watcher.Events()
+	cancel()
+
+	assert.Equal(t, StreamWatcherError, event.Type)
+	assert.IsType(t, &ResponseError{}, event.Err)
+
+	// The channel is closed once Run returns.
+	for range watcher.Events() {
+	}
+}
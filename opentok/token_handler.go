@@ -0,0 +1,270 @@
+package opentok
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenGrant defines the token an authorized user gets.
+type TokenGrant struct {
+	// The ID of the user, used for rate limiting.
+	UserID string
+
+	// The session ID the token is for.
+	SessionID string
+
+	// The role of the user in the session, publisher by default.
+	Role Role
+
+	// How long the token is valid, 24 hours by default.
+	TTL time.Duration
+
+	// The connection data of the token.
+	Data string
+
+	// The initial layout classes of the streams of the user.
+	InitialLayoutClassList []string
+}
+
+// Authorizer decides whether the user making a token request may get a token,
+// and for which session and with which options.
+type Authorizer interface {
+	// Authorize returns the token grant of the request. Return an
+	// *AuthorizationError to choose the response status code; other errors
+	// are reported as 403 Forbidden.
+	Authorize(r *http.Request) (*TokenGrant, error)
+}
+
+// AuthorizerFunc is an adapter to allow the use of ordinary functions as
+// authorizers.
+type AuthorizerFunc func(r *http.Request) (*TokenGrant, error)
+
+// Authorize calls f(r).
+func (f AuthorizerFunc) Authorize(r *http.Request) (*TokenGrant, error) {
+	return f(r)
+}
+
+// AuthorizationError defines an error returned by an authorizer.
+type AuthorizationError struct {
+	// The HTTP status code of the response.
+	StatusCode int
+
+	// The error message sent to the client.
+	Message string
+}
+
+// Error returns the error message.
+func (e *AuthorizationError) Error() string {
+	return e.Message
+}
+
+// TokenHandlerOptions defines the options of a token handler.
+type TokenHandlerOptions struct {
+	// The origins allowed to call the handler from a browser. "*" allows any
+	// origin, but only the origins listed explicitly may send credentials
+	// such as cookies. Cross-origin requests are not allowed by default.
+	AllowedOrigins []string
+
+	// The request headers allowed in cross-origin requests, "Authorization"
+	// and "Content-Type" by default.
+	AllowedHeaders []string
+
+	// The number of tokens each user may get, per second and in a burst.
+	// It is not limited by default.
+	RateLimit *RateLimit
+}
+
+// TokenResponse defines the response of a token handler.
+type TokenResponse struct {
+	// The API key associated with the project.
+	APIKey string `json:"apiKey"`
+
+	// The session ID.
+	SessionID string `json:"sessionId"`
+
+	// The token.
+	Token string `json:"token"`
+}
+
+// TokenHandler is an HTTP handler issuing tokens to the users allowed by its
+// authorizer. It responds to GET and POST requests with a TokenResponse in
+// JSON.
+type TokenHandler struct {
+	ot         *OpenTok
+	authorizer Authorizer
+	opts       TokenHandlerOptions
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// NewTokenHandler returns a token handler using the authorizer, which must not
+// be nil.
+func (ot *OpenTok) NewTokenHandler(authorizer Authorizer, opts *TokenHandlerOptions) *TokenHandler {
+	h := &TokenHandler{
+		ot:         ot,
+		authorizer: authorizer,
+		buckets:    map[string]*tokenBucket{},
+	}
+
+	if opts != nil {
+		h.opts = *opts
+	}
+
+	if len(h.opts.AllowedHeaders) == 0 {
+		h.opts.AllowedHeaders = []string{"Authorization", "Content-Type"}
+	}
+
+	return h
+}
+
+// ServeHTTP issues a token.
+func (h *TokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.setCORSHeaders(w, r)
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet, http.MethodPost:
+	default:
+		w.Header().Set("Allow", "GET, POST, OPTIONS")
+		writeJSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	grant, err := h.authorizer.Authorize(r)
+	if err != nil {
+		var authErr *AuthorizationError
+		if errors.As(err, &authErr) {
+			writeJSONError(w, authErr.StatusCode, authErr.Message)
+		} else {
+			writeJSONError(w, http.StatusForbidden, "Forbidden")
+		}
+		return
+	}
+
+	if grant == nil {
+		writeJSONError(w, http.StatusForbidden, "Forbidden")
+		return
+	}
+
+	if !h.allow(grant.UserID) {
+		w.Header().Set("Retry-After", "1")
+		writeJSONError(w, http.StatusTooManyRequests, "Too many token requests")
+		return
+	}
+
+	// The session must belong to the API key, or the token would be useless.
+	if info, err := decodeSessionID(grant.SessionID); err != nil || info.APIKey != h.ot.apiKey {
+		writeJSONError(w, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	opts := &TokenOptions{
+		Role:                   grant.Role,
+		Data:                   grant.Data,
+		InitialLayoutClassList: grant.InitialLayoutClassList,
+	}
+
+	if grant.TTL > 0 {
+		opts.ExpireTime = time.Now().Add(grant.TTL).Unix()
+	}
+
+	token, err := h.ot.GenerateToken(grant.SessionID, opts)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, &TokenResponse{
+		APIKey:    h.ot.apiKey,
+		SessionID: grant.SessionID,
+		Token:     token,
+	})
+}
+
+// Set the CORS headers if the origin of the request is allowed.
+func (h *TokenHandler) setCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	if len(h.opts.AllowedOrigins) == 0 {
+		return
+	}
+
+	// The headers depend on the origin, including when they are not set, so
+	// caches must not share responses between origins.
+	w.Header().Add("Vary", "Origin")
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+
+	listed, wildcard := false, false
+	for _, o := range h.opts.AllowedOrigins {
+		switch o {
+		case origin:
+			listed = true
+		case "*":
+			wildcard = true
+		}
+	}
+
+	// Credentials are only allowed for the origins listed explicitly, so that
+	// other websites cannot get tokens with the cookies of the user.
+	switch {
+	case listed:
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	case wildcard:
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	default:
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(h.opts.AllowedHeaders, ", "))
+	w.Header().Set("Access-Control-Max-Age", "600")
+}
+
+// Report whether the user may get another token.
+func (h *TokenHandler) allow(userID string) bool {
+	if h.opts.RateLimit == nil {
+		return true
+	}
+
+	h.mu.Lock()
+	bucket, ok := h.buckets[userID]
+	if !ok {
+		// Forget the users that have not asked for tokens lately.
+		if len(h.buckets) >= 10000 {
+			for id, b := range h.buckets {
+				if b.full() {
+					delete(h.buckets, id)
+				}
+			}
+		}
+
+		bucket = newTokenBucket(h.opts.RateLimit)
+		h.buckets[userID] = bucket
+	}
+	h.mu.Unlock()
+
+	return bucket.allow()
+}
+
+// Write v in JSON.
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Write an error message in JSON, in the same format as the OpenTok API.
+func writeJSONError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]string{"message": message})
+}
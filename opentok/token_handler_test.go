@@ -0,0 +1,159 @@
+package opentok
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTokenHandler(opts *TokenHandlerOptions) *TokenHandler {
+	return ot.NewTokenHandler(AuthorizerFunc(func(r *http.Request) (*TokenGrant, error) {
+		user := r.Header.Get("Authorization")
+		if user == "" {
+			return nil, &AuthorizationError{StatusCode: http.StatusUnauthorized, Message: "Sign in first"}
+		}
+
+		if user == "banned" {
+			return nil, fmt.Errorf("user is banned")
+		}
+
+		sessionID := r.URL.Query().Get("room")
+		if sessionID == "" {
+			sessionID = "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34"
+		}
+
+		return &TokenGrant{
+			UserID:    user,
+			SessionID: sessionID,
+			Role:      Moderator,
+			TTL:       time.Hour,
+			Data:      "userId=" + user,
+		}, nil
+	}), opts)
+}
+
+func TestTokenHandler(t *testing.T) {
+	handler := newTestTokenHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/token", nil)
+	req.Header.Set("Authorization", "alice")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+	res := &TokenResponse{}
+	assert.Nil(t, json.NewDecoder(rec.Body).Decode(res))
+	assert.Equal(t, apiKey, res.APIKey)
+	assert.Equal(t, "1_MX48eW91ciBhcGkga2V5IGhlcmU-fn4xNTc3ODY1NjAwMDAwfng3YjQ4TVFnRGYrWVFGcVBRaDh2VmZPS34", res.SessionID)
+	assert.Contains(t, res.Token, tokenSentinel)
+}
+
+func TestTokenHandler_Errors(t *testing.T) {
+	handler := newTestTokenHandler(nil)
+
+	tests := []struct {
+		method  string
+		url     string
+		user    string
+		code    int
+		message string
+	}{
+		{http.MethodGet, "/token", "", http.StatusUnauthorized, "Sign in first"},
+		{http.MethodGet, "/token", "banned", http.StatusForbidden, "Forbidden"},
+		{http.MethodGet, "/token?room=1_MX40MDAwMDAwMX5-MTU3Nzg2NTYwMDAwMH54N2I0OE1RZ0RmK1lRRnFQUWg4dlZmT0t-QX4", "alice", http.StatusBadRequest, "Invalid session ID"},
+		{http.MethodGet, "/token?room=bogus", "alice", http.StatusBadRequest, "Invalid session ID"},
+		{http.MethodDelete, "/token", "alice", http.StatusMethodNotAllowed, "Method not allowed"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.url, nil)
+		if test.user != "" {
+			req.Header.Set("Authorization", test.user)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, test.code, rec.Code, test.url)
+		assert.JSONEq(t, `{"message": "`+test.message+`"}`, rec.Body.String())
+	}
+}
+
+func TestTokenHandler_CORS(t *testing.T) {
+	handler := newTestTokenHandler(&TokenHandlerOptions{
+		AllowedOrigins: []string{"https://app.example.com"},
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/token", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "Authorization, Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+
+	req = httptest.NewRequest(http.MethodOptions, "/token", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+
+	// Responses without CORS headers must not be served to other origins.
+	req = httptest.NewRequest(http.MethodOptions, "/token", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+}
+
+func TestTokenHandler_CORSWildcard(t *testing.T) {
+	handler := newTestTokenHandler(&TokenHandlerOptions{
+		AllowedOrigins: []string{"*", "https://app.example.com"},
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/token", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+
+	// Listed origins may still send credentials.
+	req = httptest.NewRequest(http.MethodOptions, "/token", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestTokenHandler_RateLimit(t *testing.T) {
+	handler := newTestTokenHandler(&TokenHandlerOptions{
+		RateLimit: &RateLimit{Rate: 0.001, Burst: 2},
+	})
+
+	codes := []int{}
+	for _, user := range []string{"alice", "alice", "alice", "bob"} {
+		req := httptest.NewRequest(http.MethodGet, "/token", nil)
+		req.Header.Set("Authorization", user)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		codes = append(codes, rec.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusOK}, codes)
+}